@@ -0,0 +1,111 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"math"
+
+	"github.com/goulash/stat/statutil"
+)
+
+// MeanCI returns the lower and upper bounds of the confidence interval
+// of the mean of s, at the given confidence level, such as 0.95.
+//
+// The interval is mean ± t·std/√n, where t is the (1+level)/2-quantile
+// of Student's t distribution with n-1 degrees of freedom. This assumes
+// that the values are independent and that the mean is approximately
+// normally distributed.
+//
+// If s has fewer than two elements, NaN is returned for both bounds.
+func MeanCI(s Series, level float64) (lo, hi float64) {
+	return meanCI(float64(len(s)), Mean(s), Std(s), level, false)
+}
+
+// MeanCINormal is like MeanCI, but uses the quantile of the standard normal
+// distribution instead of Student's t distribution. This is only appropriate
+// for large samples, where the difference is negligible.
+func MeanCINormal(s Series, level float64) (lo, hi float64) {
+	return meanCI(float64(len(s)), Mean(s), Std(s), level, true)
+}
+
+// MoreSamples returns an estimate of how many more samples are required,
+// in addition to those in s, so that the half-width of the confidence
+// interval of the mean at the given level is at most precision·|mean|.
+// For example, a precision of 0.05 requests an interval of ±5% of the mean.
+//
+// The estimate assumes that the sample mean and standard deviation of s
+// do not change as more samples are added.
+// If s has fewer than two elements, or the mean is zero so that no number
+// of samples suffices, -1 is returned.
+func MoreSamples(s Series, level, precision float64) int64 {
+	return moreSamples(float64(len(s)), Mean(s), Std(s), level, precision)
+}
+
+// halfWidth returns the half-width of the confidence interval of the mean
+// for n samples with standard deviation std.
+func halfWidth(n, std, level float64, normal bool) float64 {
+	if n < 2 || !(0 < level && level < 1) {
+		return math.NaN()
+	}
+
+	p := (1 + level) / 2
+	var q float64
+	if normal {
+		q = statutil.NormalQuantile(p)
+	} else {
+		q = statutil.StudentTQuantile(p, n-1)
+	}
+	return q * std / math.Sqrt(n)
+}
+
+func meanCI(n, mean, std, level float64, normal bool) (lo, hi float64) {
+	h := halfWidth(n, std, level, normal)
+	return mean - h, mean + h
+}
+
+// moreSamples returns the number of samples to add to n, which need not
+// be an integer if it is a total weight.
+func moreSamples(n, mean, std, level, precision float64) int64 {
+	if n < 2 || !(precision > 0) || math.IsNaN(halfWidth(n, std, level, false)) {
+		return -1
+	}
+
+	target := precision * math.Abs(mean)
+	if halfWidth(n, std, level, false) <= target {
+		return 0
+	}
+	fits := func(m int64) bool { return halfWidth(float64(m), std, level, false) <= target }
+
+	// The normal approximation gives a lower bound on the number of samples,
+	// as the t quantile is always larger than the normal quantile. If the
+	// mean is zero, no number of samples suffices.
+	z := statutil.NormalQuantile((1 + level) / 2)
+	est := math.Ceil(math.Pow(z*std/target, 2))
+	if target == 0 || !(est < math.MaxInt64) {
+		return -1
+	}
+
+	// Find the smallest m that fits by doubling and then bisection,
+	// where lo never fits and hi always does.
+	lo, hi := int64(n), int64(est)
+	if hi <= lo {
+		hi = lo + 1
+	}
+	for !fits(hi) {
+		if hi > math.MaxInt64/2 {
+			return -1
+		}
+		lo, hi = hi, 2*hi
+	}
+	for hi-lo > 1 {
+		m := lo + (hi-lo)/2
+		if fits(m) {
+			hi = m
+		} else {
+			lo = m
+		}
+	}
+	return int64(math.Ceil(float64(hi) - n))
+}
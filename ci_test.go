@@ -0,0 +1,82 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeanCI(z *testing.T) {
+	assert := assert.New(z)
+
+	s := Series{1, 2, 3, 4, 5}
+	var r Run
+	for _, x := range s {
+		r.Add(x)
+	}
+
+	lo, hi := s.MeanCI(0.95)
+	assert.InDelta(3-1.9632431614775607, lo, 1e-9, "lower bound")
+	assert.InDelta(3+1.9632431614775607, hi, 1e-9, "upper bound")
+	rlo, rhi := r.MeanCI(0.95)
+	assert.InDelta(lo, rlo, 1e-12, "run lower bound")
+	assert.InDelta(hi, rhi, 1e-12, "run upper bound")
+
+	lo, hi = MeanCINormal(s, 0.95)
+	assert.InDelta(3-1.3859038243496777, lo, 1e-9, "normal lower bound")
+	assert.InDelta(3+1.3859038243496777, hi, 1e-9, "normal upper bound")
+
+	lo, hi = MeanCI(Series{1}, 0.95)
+	assert.True(math.IsNaN(lo) && math.IsNaN(hi), "no interval for a single value")
+}
+
+func TestMeanCIWeighted(z *testing.T) {
+	assert := assert.New(z)
+
+	// Weights are frequencies, so a weight of 10 is the same as adding
+	// the value ten times.
+	var w, f Run
+	for _, x := range []float64{1, 2, 3, 4} {
+		w.AddWeighted(x, 10)
+		f.AddN(10, x)
+	}
+	lo, hi := w.MeanCI(0.95)
+	flo, fhi := f.MeanCI(0.95)
+	assert.InDelta(2.137880236641413, lo, 1e-9, "lower bound")
+	assert.InDelta(2.862119763358587, hi, 1e-9, "upper bound")
+	assert.InDelta(flo, lo, 1e-12, "lower bound of frequencies")
+	assert.InDelta(fhi, hi, 1e-12, "upper bound of frequencies")
+	lo, hi = w.MeanCINormal(0.95)
+	flo, fhi = f.MeanCINormal(0.95)
+	assert.InDelta(flo, lo, 1e-12, "normal lower bound")
+	assert.InDelta(fhi, hi, 1e-12, "normal upper bound")
+	assert.Equal(f.MoreSamples(0.95, 0.01), w.MoreSamples(0.95, 0.01), "more samples")
+}
+
+func TestMoreSamples(z *testing.T) {
+	assert := assert.New(z)
+
+	s := Series{9, 11, 10, 12, 8}
+	assert.Equal(int64(0), MoreSamples(s, 0.95, 0.5), "precision is already reached")
+	for _, p := range []float64{0.1, 0.01} {
+		m := s.Len() + int(MoreSamples(s, 0.95, p))
+		h := halfWidth(float64(m), s.Std(), 0.95, false)
+		assert.True(h <= p*s.Mean(), "precision %v is reached with %d samples", p, m)
+		h = halfWidth(float64(m-1), s.Std(), 0.95, false)
+		assert.True(h > p*s.Mean(), "precision %v is not reached with %d samples", p, m-1)
+	}
+
+	var r Run
+	r.Add(1)
+	assert.Equal(int64(-1), r.MoreSamples(0.95, 0.1), "cannot estimate from one value")
+	r.Add(1)
+	assert.Equal(int64(0), r.MoreSamples(0.95, 0.1), "no variance, no more samples")
+
+	assert.Equal(int64(-1), MoreSamples(Series{-1, 1, -2, 2}, 0.95, 0.1), "zero mean never reaches the precision")
+	assert.Equal(int64(-1), MoreSamples(Series{1e-300, 1e300, -1e300}, 0.95, 0.1), "too many samples to count")
+}
@@ -4,11 +4,165 @@
 
 package dist
 
-import "math/rand"
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/goulash/stat/statutil"
+)
 
 // Binomial distribution with parameter n and p.
 //
-// TODO.
+// This is the distribution of the number of successes in n independent
+// trials, each of which succeeds with probability p.
 type Binomial struct {
 	r *rand.Rand
+	n int64
+	p float64
+}
+
+// NewBinomial returns a binomial distribution of n trials with success
+// probability p, where n >= 0 and p is in [0, 1].
+//
+// The random source s is only used for Int63, so if the distribution is
+// not sampled, s may be nil.
+func NewBinomial(s rand.Source, n int64, p float64) *Binomial {
+	if n < 0 {
+		panic("n must be non-negative")
+	}
+	if !(0 <= p && p <= 1) {
+		panic("p must be in [0, 1]")
+	}
+
+	b := &Binomial{n: n, p: p}
+	if s != nil {
+		b.r = rand.New(s)
+	}
+	return b
+}
+
+func (b *Binomial) String() string {
+	return fmt.Sprintf("binomial [%v %v]", b.n, b.p)
+}
+
+// Int63 returns a random number of successes.
+//
+// The time required grows with log(n), so large n are no problem.
+func (b *Binomial) Int63() int64 {
+	return binomialInt63(b.r, b.n, b.p)
+}
+
+// PMF returns the probability of exactly k successes.
+func (b *Binomial) PMF(k int64) float64 {
+	switch {
+	case k < 0 || k > b.n:
+		return 0
+	case b.p == 0:
+		return indicator(k == 0)
+	case b.p == 1:
+		return indicator(k == b.n)
+	}
+	return math.Exp(lchoose(b.n, k) + float64(k)*math.Log(b.p) + float64(b.n-k)*math.Log1p(-b.p))
+}
+
+// CDF returns the probability of at most k successes.
+func (b *Binomial) CDF(k int64) float64 {
+	switch {
+	case k < 0:
+		return 0
+	case k >= b.n:
+		return 1
+	case b.p == 0:
+		return 1
+	case b.p == 1:
+		return 0
+	}
+	return statutil.IncBeta(float64(b.n-k), float64(k+1), 1-b.p)
+}
+
+// Quantile returns the smallest k such that CDF(k) >= p.
+func (b *Binomial) Quantile(p float64) int64 {
+	return searchQuantile(b.CDF, p, 0, b.n)
+}
+
+func (b *Binomial) Mean() float64 { return float64(b.n) * b.p }
+func (b *Binomial) Var() float64  { return float64(b.n) * b.p * (1 - b.p) }
+func (b *Binomial) Std() float64  { return math.Sqrt(b.Var()) }
+
+func (b *Binomial) Skewness() float64 {
+	if b.Var() == 0 {
+		return math.NaN()
+	}
+	return (1 - 2*b.p) / b.Std()
+}
+
+func (b *Binomial) ExcessKurtosis() float64 {
+	if b.Var() == 0 {
+		return math.NaN()
+	}
+	return (1 - 6*b.p*(1-b.p)) / b.Var()
+}
+
+// Moment returns the k-th raw moment, computed from the factorial
+// moments n(n-1)...(n-j+1) p^j.
+func (b *Binomial) Moment(k int) float64 {
+	return factorialMoments(k, func(j int) float64 {
+		m := 1.0
+		for i := 0; i < j; i++ {
+			m *= float64(b.n-int64(i)) * b.p
+		}
+		return m
+	})
+}
+
+// lchoose returns the logarithm of the binomial coefficient n over k.
+func lchoose(n, k int64) float64 {
+	return statutil.LogGamma(float64(n+1)) - statutil.LogGamma(float64(k+1)) -
+		statutil.LogGamma(float64(n-k+1))
+}
+
+func indicator(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// searchQuantile returns the smallest k in [lo, hi] such that cdf(k) >= p,
+// by binary search. If there is no such k, hi is returned.
+func searchQuantile(cdf func(int64) float64, p float64, lo, hi int64) int64 {
+	if p <= 0 {
+		return lo
+	}
+	for lo < hi {
+		m := lo + (hi-lo)/2
+		if cdf(m) >= p {
+			hi = m
+		} else {
+			lo = m + 1
+		}
+	}
+	return lo
+}
+
+// searchUnboundedQuantile is like searchQuantile, but for distributions
+// whose support has no upper bound. The upper bound is found by doubling,
+// starting from guess.
+func searchUnboundedQuantile(cdf func(int64) float64, p float64, guess int64) int64 {
+	if p >= 1 {
+		return math.MaxInt64
+	}
+	var lo int64
+	hi := guess
+	if hi < 1 {
+		hi = 1
+	}
+	for cdf(hi) < p {
+		if hi > math.MaxInt64/2 {
+			return math.MaxInt64
+		}
+		lo, hi = hi+1, 2*hi
+	}
+	return searchQuantile(cdf, p, lo, hi)
 }
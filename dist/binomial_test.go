@@ -0,0 +1,197 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+type sampler interface {
+	String() string
+	Int63() int64
+	Mean() float64
+	Var() float64
+}
+
+var (
+	_ DiscreteDist = (*Binomial)(nil)
+	_ DiscreteDist = (*Geometric)(nil)
+	_ DiscreteDist = (*NegativeBinomial)(nil)
+	_ DiscreteDist = (*Poisson)(nil)
+	_ DiscreteDist = (*Stairs)(nil)
+	_ DiscreteDist = (*UniformDiscrete)(nil)
+)
+
+// checkDiscrete checks that the PMF, CDF, Quantile, and moments of d
+// are consistent with each other and with samples from d. The support
+// is assumed to lie within [0, max].
+func checkDiscrete(z *testing.T, d interface {
+	DiscreteDist
+	Int63() int64
+}, max int64) {
+	var cum, mean, m2 float64
+	for k := int64(0); k <= max; k++ {
+		p := d.PMF(k)
+		cum += p
+		mean += float64(k) * p
+		m2 += float64(k) * float64(k) * p
+		if c := d.CDF(k); !(math.Abs(c-cum) <= 1e-9) {
+			z.Errorf("%v.CDF(%d) = %v, want %v", d, k, c, cum)
+		}
+		if c := d.CDF(k); p > 1e-9 && c < 1-1e-9 {
+			if q := d.Quantile(c - 1e-12); q != k {
+				z.Errorf("%v.Quantile(%v) = %d, want %d", d, c, q, k)
+			}
+		}
+	}
+	if !(math.Abs(cum-1) <= 1e-9) {
+		z.Errorf("%v: sum of PMF = %v, want 1", d, cum)
+	}
+	if !(math.Abs(mean-d.Mean()) <= 1e-6) {
+		z.Errorf("%v.Mean() = %v, want %v", d, d.Mean(), mean)
+	}
+	if v := m2 - mean*mean; !(math.Abs(v-d.Var()) <= 1e-6*math.Max(1, v)) {
+		z.Errorf("%v.Var() = %v, want %v", d, d.Var(), v)
+	}
+	if d.PMF(-1) != 0 || d.CDF(-1) != 0 {
+		z.Errorf("%v: negative values should have zero probability", d)
+	}
+
+	checkSamples(z, d)
+}
+
+// checkSamples compares the sample mean and variance of d with the
+// analytic moments.
+func checkSamples(z *testing.T, d sampler) {
+	const n = 100000
+	var mean, s float64
+	for i := 0; i < n; i++ {
+		x := float64(d.Int63())
+		m := mean + (x-mean)/float64(i+1)
+		s += (x - mean) * (x - m)
+		mean = m
+	}
+	v := s / n
+	std := math.Sqrt(d.Var())
+	if math.Abs(mean-d.Mean()) > 5*std/math.Sqrt(n)+1e-12 {
+		z.Errorf("%v: sample mean = %v, want %v", d, mean, d.Mean())
+	}
+	if math.Abs(v-d.Var()) > 0.05*d.Var()+1e-12 {
+		z.Errorf("%v: sample variance = %v, want %v", d, v, d.Var())
+	}
+}
+
+func TestBinomial(z *testing.T) {
+	src := rand.NewSource(1)
+	checkDiscrete(z, NewBinomial(src, 10, 0.3), 10)
+	checkDiscrete(z, NewBinomial(src, 100, 0.95), 100)
+	checkDiscrete(z, NewBinomial(src, 1, 0.5), 1)
+	checkDiscrete(z, NewBinomial(src, 5, 0), 5)
+	checkDiscrete(z, NewBinomial(src, 5, 1), 5)
+
+	// Large n should be sampled quickly and correctly.
+	checkSamples(z, NewBinomial(src, 1000000000, 0.001))
+	checkSamples(z, NewBinomial(src, 1<<40, 0.5))
+
+	b := NewBinomial(nil, 20, 0.5)
+	if q := b.Quantile(0.5); q != 10 {
+		z.Errorf("%v.Quantile(0.5) = %d, want 10", b, q)
+	}
+	if q := b.Quantile(1); q != 20 {
+		z.Errorf("%v.Quantile(1) = %d, want 20", b, q)
+	}
+}
+
+func TestGeometric(z *testing.T) {
+	src := rand.NewSource(1)
+	checkDiscrete(z, NewGeometric(src, 0.3), 200)
+	checkDiscrete(z, NewGeometric(src, 0.9), 50)
+	checkDiscrete(z, NewGeometric(src, 1), 10)
+
+	g := NewGeometric(nil, 0.5)
+	if q := g.Quantile(1); q != math.MaxInt64 {
+		z.Errorf("%v.Quantile(1) = %d, want MaxInt64", g, q)
+	}
+
+	// With p = 1, the first trial always succeeds.
+	g = NewGeometric(nil, 1)
+	for k, want := range []float64{1, 0, 0} {
+		if p := g.PMF(int64(k)); p != want {
+			z.Errorf("%v.PMF(%d) = %v, want %v", g, k, p, want)
+		}
+		if p := g.CDF(int64(k)); p != 1 {
+			z.Errorf("%v.CDF(%d) = %v, want 1", g, k, p)
+		}
+	}
+}
+
+func TestNegativeBinomial(z *testing.T) {
+	src := rand.NewSource(1)
+	checkDiscrete(z, NewNegativeBinomial(src, 1, 0.3), 200)
+	checkDiscrete(z, NewNegativeBinomial(src, 5, 0.5), 200)
+	checkDiscrete(z, NewNegativeBinomial(src, 2.5, 0.2), 400)
+	checkSamples(z, NewNegativeBinomial(src, 10, 0.001))
+
+	// With r = 1, this is the geometric distribution.
+	nb, g := NewNegativeBinomial(nil, 1, 0.25), NewGeometric(nil, 0.25)
+	for k := int64(0); k < 20; k++ {
+		if math.Abs(nb.PMF(k)-g.PMF(k)) > 1e-15 {
+			z.Errorf("%v.PMF(%d) = %v, want %v", nb, k, nb.PMF(k), g.PMF(k))
+		}
+	}
+}
+
+func TestPoisson(z *testing.T) {
+	src := rand.NewSource(1)
+	checkDiscrete(z, NewPoisson(src, 0.5), 50)
+	checkDiscrete(z, NewPoisson(src, 4), 100)
+	checkDiscrete(z, NewPoisson(src, 30), 200)
+	for _, l := range []float64{1000, 1e7} {
+		checkSamples(z, NewPoisson(src, l))
+	}
+}
+
+func TestStairsDiscrete(z *testing.T) {
+	src := rand.NewSource(1)
+	checkDiscrete(z, NewStairs(src, 0.0, 0.3, 0.6, 0.6, 0.9), 4)
+	checkDiscrete(z, NewStairs(src, 1, 2, 3), 2)
+}
+
+func TestUniformDiscrete(z *testing.T) {
+	src := rand.NewSource(1)
+	checkDiscrete(z, NewUniformDiscrete(src, 0, 6), 5)
+	checkDiscrete(z, NewUniformDiscrete(src, 3, 10), 9)
+
+	// With b-a = 1, there is a single value and no spread.
+	u := NewUniformDiscrete(src, 4, 5)
+	if u.Var() != 0 || !math.IsNaN(u.Skewness()) || !math.IsNaN(u.ExcessKurtosis()) {
+		z.Errorf("%v: var %v, skewness %v, excess kurtosis %v, want 0, NaN, NaN", u, u.Var(), u.Skewness(), u.ExcessKurtosis())
+	}
+
+	// With b-a = 2, there are two values, with a kurtosis of -2.
+	u = NewUniformDiscrete(src, 4, 6)
+	if u.Skewness() != 0 || math.Abs(u.ExcessKurtosis()+2) > 1e-15 {
+		z.Errorf("%v: skewness %v, excess kurtosis %v, want 0, -2", u, u.Skewness(), u.ExcessKurtosis())
+	}
+}
+
+func TestAsDist(z *testing.T) {
+	d := AsDist(NewBinomial(nil, 10, 0.5))
+	b := NewBinomial(nil, 10, 0.5)
+	if p := d.P(2.5); p != b.CDF(2) {
+		z.Errorf("%v.P(2.5) = %v, want %v", d, p, b.CDF(2))
+	}
+	if p := d.P(-0.5); p != 0 {
+		z.Errorf("%v.P(-0.5) = %v, want 0", d, p)
+	}
+	if x := d.Q(0.5); x != 5 {
+		z.Errorf("%v.Q(0.5) = %v, want 5", d, x)
+	}
+	if x := AsDist(NewGeometric(nil, 0.5)).Q(1); !math.IsInf(x, 1) {
+		z.Errorf("unbounded quantile should be +Inf, got %v", x)
+	}
+}
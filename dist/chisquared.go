@@ -0,0 +1,121 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/goulash/stat/statutil"
+)
+
+// ChiSquared distribution with k degrees of freedom.
+//
+// This is the distribution of the sum of the squares of k independent
+// standard normal random variables.
+type ChiSquared struct {
+	r *rand.Rand
+	k float64
+}
+
+// NewChiSquared returns a chi-squared distribution with k degrees of freedom.
+//
+// The random source s is only used for Float64, so if the distribution is
+// only used for P and Q, s may be nil.
+func NewChiSquared(s rand.Source, k float64) *ChiSquared {
+	if !(k > 0) {
+		panic("degrees of freedom must be positive")
+	}
+
+	c := &ChiSquared{k: k}
+	if s != nil {
+		c.r = rand.New(s)
+	}
+	return c
+}
+
+func (c *ChiSquared) String() string {
+	return fmt.Sprintf("chi-squared [%v]", c.k)
+}
+
+// Float64 returns a random value, since the chi-squared distribution with
+// k degrees of freedom is the gamma distribution with shape k/2 and scale 2.
+func (c *ChiSquared) Float64() float64 {
+	return 2 * gammaFloat64(c.r, c.k/2)
+}
+
+func (c *ChiSquared) P(x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	return statutil.IncGammaP(c.k/2, x/2)
+}
+
+// Survival returns the probability that a value is greater than x,
+// which is 1 - P(x) but retains its precision in the upper tail.
+func (c *ChiSquared) Survival(x float64) float64 {
+	if x <= 0 {
+		return 1
+	}
+	return statutil.IncGammaQ(c.k/2, x/2)
+}
+
+func (c *ChiSquared) Q(p float64) float64 {
+	if !isProb(p) {
+		return math.NaN()
+	} else if p == 0 {
+		return 0
+	} else if p == 1 {
+		return math.Inf(1)
+	}
+	return 2 * statutil.IncGammaPInv(c.k/2, p)
+}
+
+// PDF returns the probability density at x.
+func (c *ChiSquared) PDF(x float64) float64 {
+	if x < 0 {
+		return 0
+	} else if x == 0 {
+		switch {
+		case c.k < 2:
+			return math.Inf(1)
+		case c.k == 2:
+			return 0.5
+		default:
+			return 0
+		}
+	}
+	return math.Exp(c.LogPDF(x))
+}
+
+func (c *ChiSquared) LogPDF(x float64) float64 {
+	if x < 0 || (x == 0 && c.k > 2) {
+		return math.Inf(-1)
+	} else if x == 0 {
+		return math.Log(c.PDF(0))
+	}
+	h := c.k / 2
+	return (h-1)*math.Log(x) - x/2 - h*math.Ln2 - statutil.LogGamma(h)
+}
+
+func (c *ChiSquared) Mean() float64 { return c.k }
+func (c *ChiSquared) Var() float64  { return 2 * c.k }
+func (c *ChiSquared) Std() float64  { return math.Sqrt(2 * c.k) }
+
+func (c *ChiSquared) Skewness() float64       { return math.Sqrt(8 / c.k) }
+func (c *ChiSquared) ExcessKurtosis() float64 { return 12 / c.k }
+
+// Moment returns the n-th raw moment, k(k+2)(k+4)...(k+2n-2).
+func (c *ChiSquared) Moment(n int) float64 {
+	if n < 0 {
+		return math.NaN()
+	}
+	m := 1.0
+	for i := 0; i < n; i++ {
+		m *= c.k + 2*float64(i)
+	}
+	return m
+}
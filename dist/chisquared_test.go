@@ -0,0 +1,51 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestChiSquared(z *testing.T) {
+	tests := []struct {
+		K, P, X float64
+	}{
+		{1, 0.95, 3.841458820694124},
+		{5, 0.95, 11.070497693516351},
+		{10, 0.99, 23.209251158954356},
+		{35, 0.95, 49.80184956820188},
+		{2, 0.5, 2 * math.Ln2},
+		{4, 0.05, 0.7107230213973241},
+	}
+	for _, t := range tests {
+		c := NewChiSquared(nil, t.K)
+		if x := c.Q(t.P); math.Abs(x-t.X) > 1e-9*t.X {
+			z.Errorf("ChiSquared(%v).Q(%v) = %v, want %v", t.K, t.P, x, t.X)
+		}
+		if p := c.P(t.X); math.Abs(p-t.P) > 1e-12 {
+			z.Errorf("ChiSquared(%v).P(%v) = %v, want %v", t.K, t.X, p, t.P)
+		}
+		if q := c.Survival(t.X); math.Abs(q-(1-t.P)) > 1e-12 {
+			z.Errorf("ChiSquared(%v).Survival(%v) = %v, want %v", t.K, t.X, q, 1-t.P)
+		}
+	}
+
+	// With two degrees of freedom, the survival function is exp(-x/2).
+	if q, want := NewChiSquared(nil, 2).Survival(100), math.Exp(-50); math.Abs(q-want) > 1e-12*want {
+		z.Errorf("ChiSquared(2).Survival(100) = %v, want %v", q, want)
+	}
+
+	c := NewChiSquared(rand.NewSource(1), 7)
+	var sum float64
+	const n = 100000
+	for i := 0; i < n; i++ {
+		sum += c.Float64()
+	}
+	if m := sum / n; math.Abs(m-c.Mean()) > 0.05 {
+		z.Errorf("ChiSquared(7) sample mean = %v, want %v", m, c.Mean())
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestConstants(z *testing.T) {
+	if !math.IsNaN(NaN) || !math.IsInf(Inf, 1) || !math.IsInf(NegInf, -1) {
+		z.Errorf("NaN, Inf, NegInf = %v, %v, %v", NaN, Inf, NegInf)
+	}
+}
+
+// TestConformance checks the contract of Dist that every distribution
+// must follow: the behaviour of P and Q at the boundaries and for invalid
+// arguments, that they are monotone and inverse to each other, and that
+// random values lie within the support.
+func TestConformance(z *testing.T) {
+	src := rand.NewSource(0)
+	tests := []Dist{
+		NewUniform(src, -1, 3),
+		NewExponential(src, 2.5),
+		NewNormal(src, 2, 3),
+		NewLogNormalMuSigma(src, 0.5, 0.4),
+		NewChiSquared(src, 1),
+		NewChiSquared(src, 5),
+		NewStudentT(src, 1),
+		NewStudentT(src, 9),
+		NewF(src, 1, 10),
+		NewF(src, 5, 20),
+		AsDist(NewBinomial(src, 10, 0.3)),
+		AsDist(NewPoisson(src, 3)),
+		AsDist(NewGeometric(src, 0.2)),
+		AsDist(NewNegativeBinomial(src, 2.5, 0.4)),
+		AsDist(NewUniformDiscrete(src, -2, 5)),
+		AsDist(NewStairs(src, 0.2, 0.5, 0.9)),
+	}
+	ps := []float64{0, 1e-6, 0.01, 0.1, 0.25, 0.5, 0.75, 0.9, 0.99, 1 - 1e-6, 1}
+
+	for _, d := range tests {
+		_, discrete := d.(discreteDist)
+
+		if p := d.P(math.NaN()); !math.IsNaN(p) {
+			z.Errorf("%v.P(NaN) = %v, want NaN", d, p)
+		}
+		if p := d.P(math.Inf(-1)); p != 0 {
+			z.Errorf("%v.P(-Inf) = %v, want 0", d, p)
+		}
+		if p := d.P(math.Inf(1)); p != 1 {
+			z.Errorf("%v.P(+Inf) = %v, want 1", d, p)
+		}
+		for _, p := range []float64{math.NaN(), math.Inf(-1), -0.1, 1.1, math.Inf(1)} {
+			if x := d.Q(p); !math.IsNaN(x) {
+				z.Errorf("%v.Q(%v) = %v, want NaN", d, p, x)
+			}
+		}
+
+		// Q(0) and Q(1) are the bounds of the support, so nothing lies
+		// below the one, and something lies below the other, unless
+		// it is infinite.
+		lo, hi := d.Q(0), d.Q(1)
+		if !math.IsInf(lo, -1) {
+			below := lo
+			if discrete {
+				below = lo - 1
+			}
+			if p := d.P(below); p != 0 {
+				z.Errorf("%v.P(%v) = %v below Q(0), want 0", d, below, p)
+			}
+		}
+		if !math.IsInf(hi, 1) {
+			below := hi - 1e-9*math.Max(1, math.Abs(hi))
+			if discrete {
+				below = hi - 1
+			}
+			if p := d.P(below); !(p < 1) {
+				z.Errorf("%v.P(%v) = %v below Q(1) = %v, want less than 1", d, below, p, hi)
+			}
+		}
+
+		var lastX, lastP float64 = math.Inf(-1), 0
+		for _, p := range ps {
+			x := d.Q(p)
+			if math.IsNaN(x) || x < lastX {
+				z.Errorf("%v.Q(%v) = %v, want at least %v", d, p, x, lastX)
+			}
+			q := d.P(x)
+			if !(0 <= q && q <= 1) || q < lastP {
+				z.Errorf("%v.P(%v) = %v, want in [%v, 1]", d, x, q, lastP)
+			}
+			lastX, lastP = x, q
+
+			if p == 0 || p == 1 {
+				continue
+			}
+			if discrete {
+				if q < p || d.P(x-1) >= p {
+					z.Errorf("%v.Q(%v) = %v is not the smallest with P >= p", d, p, x)
+				}
+			} else if math.Abs(q-p) > 1e-9 {
+				z.Errorf("%v.P(Q(%v)) = %v", d, p, q)
+			}
+		}
+
+		// Random values lie within the support, and roughly follow P.
+		c, ok := d.(Continuous)
+		if !ok {
+			continue
+		}
+		const n = 10000
+		quartiles := []float64{d.Q(0.25), d.Q(0.5), d.Q(0.75)}
+		counts := make([]int, len(quartiles))
+		for i := 0; i < n; i++ {
+			x := c.Float64()
+			if !(lo <= x && x <= hi) {
+				z.Errorf("%v.Float64() = %v, not in [%v, %v]", d, x, lo, hi)
+				break
+			}
+			for j, q := range quartiles {
+				if x <= q {
+					counts[j]++
+				}
+			}
+		}
+		for j, q := range quartiles {
+			if f := float64(counts[j]) / n; math.Abs(f-d.P(q)) > 0.02 {
+				z.Errorf("%v: fraction of values at most %v is %v, want %v", d, q, f, d.P(q))
+			}
+		}
+	}
+}
+
+func TestLegacyQ(z *testing.T) {
+	src := rand.NewSource(0)
+	tests := []interface {
+		String() string
+		Q(float64) float64
+		Quantile(float64) int64
+	}{
+		NewUniformDiscrete(src, -2, 5),
+		NewUniformDiscrete(src, 0, 4),
+		NewStairs(src, 0.2, 0.5, 0.9),
+	}
+	for _, d := range tests {
+		for _, p := range []float64{math.NaN(), -0.1, 1.1} {
+			if x := d.Q(p); !math.IsNaN(x) {
+				z.Errorf("%v.Q(%v) = %v, want NaN", d, p, x)
+			}
+		}
+		// Q agrees with Quantile, also where p is a step of the CDF.
+		for _, p := range []float64{0, 0.1, 0.2, 0.25, 0.5, 0.75, 0.9, 1} {
+			if x, k := d.Q(p), d.Quantile(p); x != float64(k) {
+				z.Errorf("%v.Q(%v) = %v, want Quantile = %d", d, p, x, k)
+			}
+		}
+	}
+
+	u := NewUniformDiscrete(src, 0, 4)
+	if x := u.Q(0.25); x != 0 {
+		z.Errorf("%v.Q(0.25) = %v, want 0", u, x)
+	}
+	if x := u.Q(1); x != 3 {
+		z.Errorf("%v.Q(1) = %v, want 3, as 4 is not in the support", u, x)
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestDensity(z *testing.T) {
+	src := rand.NewSource(0)
+	tests := []interface {
+		Density
+		Dist
+	}{
+		NewUniform(src, -1, 3),
+		NewExponential(src, 2.5),
+		NewNormal(src, 2, 3),
+		NewLogNormalMuSigma(src, 0.5, 0.4),
+		NewChiSquared(src, 1),
+		NewChiSquared(src, 5),
+		NewStudentT(src, 1),
+		NewStudentT(src, 9),
+		NewF(src, 1, 10),
+		NewF(src, 5, 20),
+	}
+
+	for _, d := range tests {
+		for _, p := range []float64{0.01, 0.1, 0.3, 0.5, 0.7, 0.9, 0.99} {
+			x := d.Q(p)
+			h := 1e-6 * math.Max(1, math.Abs(x))
+			deriv := (d.P(x+h) - d.P(x-h)) / (2 * h)
+			if math.Abs(d.PDF(x)-deriv) > 1e-5*math.Max(1, deriv) {
+				z.Errorf("%v.PDF(%v) = %v, want %v", d, x, d.PDF(x), deriv)
+			}
+			if math.Abs(d.LogPDF(x)-math.Log(d.PDF(x))) > 1e-12 {
+				z.Errorf("%v.LogPDF(%v) = %v, want %v", d, x, d.LogPDF(x), math.Log(d.PDF(x)))
+			}
+		}
+	}
+
+	// The hyper-exponential distribution has no P yet, so check it
+	// against its components.
+	e := NewHyperExponential(src, []float64{0.25, 1}, []float64{1, 4})
+	e1, e4 := NewExponential(src, 1), NewExponential(src, 4)
+	for _, x := range []float64{0, 0.5, 2, 100} {
+		want := 0.25*e1.PDF(x) + 0.75*e4.PDF(x)
+		if math.Abs(e.PDF(x)-want) > 1e-15 {
+			z.Errorf("%v.PDF(%v) = %v, want %v", e, x, e.PDF(x), want)
+		}
+		if math.Abs(e.LogPDF(x)-math.Log(want)) > 1e-12 {
+			z.Errorf("%v.LogPDF(%v) = %v, want %v", e, x, e.LogPDF(x), math.Log(want))
+		}
+	}
+	if l := e.LogPDF(1e4); math.IsInf(l, 0) || math.Abs(l-(math.Log(0.25)-1e4)) > 1e-9 {
+		z.Errorf("%v.LogPDF(1e4) = %v, want %v", e, l, math.Log(0.25)-1e4)
+	}
+
+	n := NewNormal(src, 0, 1)
+	if l, want := LogLikelihood(n, []float64{0, 1}), -math.Log(2*math.Pi)-0.5; math.Abs(l-want) > 1e-15 {
+		z.Errorf("LogLikelihood = %v, want %v", l, want)
+	}
+	if p := Interval(n, -1, 1); math.Abs(p-0.6826894921370859) > 1e-12 {
+		z.Errorf("Interval(%v, -1, 1) = %v", n, p)
+	}
+}
@@ -5,13 +5,22 @@
 // Package dist provides statistical probability distributions for random variables.
 package dist
 
-// Nabbed these from math...
-const (
-	NaN    = 0x7FF8000000000001
-	Inf    = 0x7FF0000000000000
-	NegInf = 0xFFF0000000000000
+import "math"
+
+// NaN, Inf, and NegInf are the IEEE 754 not-a-number, positive infinity,
+// and negative infinity, as returned by math.NaN and math.Inf.
+var (
+	NaN    = math.NaN()
+	Inf    = math.Inf(1)
+	NegInf = math.Inf(-1)
 )
 
+// isProb reports whether p is a probability, that is in [0, 1].
+// It is false if p is NaN.
+func isProb(p float64) bool {
+	return 0 <= p && p <= 1
+}
+
 type Discrete interface {
 	Int63() int64
 }
@@ -26,6 +35,8 @@ type DistP interface {
 
 	// P returns the probability that a value from the distribution is less than x.
 	// That is, P represents the cumulative probability function of the distribution.
+	//
+	// P is 0 at -Inf and 1 at +Inf, and NaN if x is NaN.
 	P(x float64) (p float64)
 }
 
@@ -35,13 +46,103 @@ type Dist interface {
 	DistP
 
 	// Q returns the p-quantile of the distribution, this is the inverse CDF function.
+	//
+	// Q(0) and Q(1) are the lower and upper bounds of the support, which
+	// are -Inf or +Inf if it is unbounded. If p is not in [0, 1], Q is NaN.
 	Q(p float64) (x float64)
 }
 
-// PDF returns the probability that a value lands between a and b, where a <= b.
-func PDF(d DistP, a, b float64) (p float64) {
+// DiscreteDist is implemented by distributions over the integers.
+//
+// Because the signatures of P and Q are in terms of float64, discrete
+// distributions cannot implement Dist directly; use AsDist for that.
+type DiscreteDist interface {
+	// Name of the distribution
+	String() string
+
+	// PMF returns the probability that a value from the distribution is exactly k.
+	PMF(k int64) float64
+
+	// CDF returns the probability that a value from the distribution is at most k.
+	CDF(k int64) float64
+
+	// Quantile returns the smallest k such that CDF(k) >= p.
+	Quantile(p float64) int64
+
+	Mean() float64
+	Var() float64
+}
+
+// AsDist returns a Dist for the discrete distribution d.
+//
+// The returned P(x) is d.CDF(⌊x⌋) and Q(p) is d.Quantile(p), so that
+// discrete distributions can be used wherever a Dist is expected.
+func AsDist(d DiscreteDist) Dist {
+	return discreteDist{d}
+}
+
+type discreteDist struct {
+	d DiscreteDist
+}
+
+func (d discreteDist) String() string { return d.d.String() }
+
+func (d discreteDist) P(x float64) float64 {
+	if math.IsNaN(x) {
+		return math.NaN()
+	} else if math.IsInf(x, 1) || x >= math.MaxInt64 {
+		return 1
+	} else if math.IsInf(x, -1) || x < math.MinInt64 {
+		return 0
+	}
+	return d.d.CDF(int64(math.Floor(x)))
+}
+
+func (d discreteDist) Q(p float64) float64 {
+	if !isProb(p) {
+		return math.NaN()
+	}
+	k := d.d.Quantile(p)
+	if k == math.MaxInt64 {
+		return math.Inf(1)
+	}
+	return float64(k)
+}
+
+// Density is implemented by continuous distributions with a probability
+// density function.
+type Density interface {
+	// PDF returns the probability density at x.
+	PDF(x float64) float64
+
+	// LogPDF returns the natural logarithm of the probability density at x.
+	// This is more accurate than log(PDF(x)) in the tails, and is what
+	// log-likelihoods are built from.
+	LogPDF(x float64) float64
+}
+
+// LogLikelihood returns the sum of the log densities of the values xs.
+func LogLikelihood(d Density, xs []float64) float64 {
+	var l float64
+	for _, x := range xs {
+		l += d.LogPDF(x)
+	}
+	return l
+}
+
+// Interval returns the probability that a value lands between a and b,
+// where a <= b. If b < a, -1 is returned.
+func Interval(d DistP, a, b float64) (p float64) {
 	if b < a {
 		return -1
 	}
 	return d.P(b) - d.P(a)
 }
+
+// PDF returns the probability that a value lands between a and b, where a <= b.
+//
+// Deprecated: Despite its name, this is not a density; use Interval instead,
+// or the PDF method of distributions that implement Density.
+func PDF(d DistP, a, b float64) (p float64) {
+	return Interval(d, a, b)
+}
@@ -37,19 +37,47 @@ func (e *Exponential) P(x float64) float64 {
 		return 0
 	}
 
-	return 1 - math.Exp(-e.lambda*x)
+	return -math.Expm1(-e.lambda * x)
 }
 
 func (e *Exponential) Q(p float64) float64 {
-	if p < 0 {
-		return 0
+	if !isProb(p) {
+		return math.NaN()
 	} else if p == 1 {
-		return Inf
+		return math.Inf(1)
+	}
+
+	return -math.Log1p(-p) / e.lambda
+}
+
+// PDF returns the probability density at x, λ exp(-λx).
+func (e *Exponential) PDF(x float64) float64 {
+	if x < 0 {
+		return 0
 	}
+	return e.lambda * math.Exp(-e.lambda*x)
+}
 
-	return -math.Log(1-p) / e.lambda
+func (e *Exponential) LogPDF(x float64) float64 {
+	if x < 0 {
+		return math.Inf(-1)
+	}
+	return math.Log(e.lambda) - e.lambda*x
 }
 
 func (e *Exponential) Mean() float64 {
 	return 1 / e.lambda
 }
+
+func (e *Exponential) Var() float64            { return 1 / (e.lambda * e.lambda) }
+func (e *Exponential) Std() float64            { return 1 / e.lambda }
+func (e *Exponential) Skewness() float64       { return 2 }
+func (e *Exponential) ExcessKurtosis() float64 { return 6 }
+
+// Moment returns the n-th raw moment, n!/λ^n.
+func (e *Exponential) Moment(n int) float64 {
+	if n < 0 {
+		return math.NaN()
+	}
+	return math.Gamma(float64(n+1)) / math.Pow(e.lambda, float64(n))
+}
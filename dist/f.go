@@ -0,0 +1,174 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/goulash/stat/statutil"
+)
+
+// F is the F distribution, also known as the Fisher-Snedecor distribution,
+// with d1 and d2 degrees of freedom.
+//
+// This is the distribution of (U1/d1)/(U2/d2), where U1 and U2 are
+// independent chi-squared variables with d1 and d2 degrees of freedom.
+// It is the distribution of the F statistic in the analysis of variance.
+type F struct {
+	r  *rand.Rand
+	d1 float64
+	d2 float64
+}
+
+// NewF returns the F distribution with d1 and d2 degrees of freedom.
+//
+// The random source s is only used for Float64, so if the distribution is
+// only used for P and Q, s may be nil.
+func NewF(s rand.Source, d1, d2 float64) *F {
+	if !(d1 > 0 && d2 > 0) {
+		panic("degrees of freedom must be positive")
+	}
+
+	f := &F{d1: d1, d2: d2}
+	if s != nil {
+		f.r = rand.New(s)
+	}
+	return f
+}
+
+func (f *F) String() string {
+	return fmt.Sprintf("F [%v %v]", f.d1, f.d2)
+}
+
+// DF returns the degrees of freedom of the numerator and denominator.
+func (f *F) DF() (d1, d2 float64) { return f.d1, f.d2 }
+
+func (f *F) Float64() float64 {
+	u1 := 2 * gammaFloat64(f.r, f.d1/2)
+	u2 := 2 * gammaFloat64(f.r, f.d2/2)
+	return (u1 / f.d1) / (u2 / f.d2)
+}
+
+func (f *F) P(x float64) float64 {
+	if x <= 0 {
+		return 0
+	} else if math.IsInf(x, 1) {
+		return 1
+	}
+	return 1 - f.Survival(x)
+}
+
+// Survival returns the probability that a value is greater than x,
+// which is 1 - P(x) but retains its precision in the upper tail.
+func (f *F) Survival(x float64) float64 {
+	if x <= 0 {
+		return 1
+	} else if math.IsInf(x, 1) {
+		return 0
+	}
+	return statutil.IncBeta(f.d2/2, f.d1/2, f.d2/(f.d2+f.d1*x))
+}
+
+func (f *F) Q(p float64) float64 {
+	if !isProb(p) {
+		return math.NaN()
+	} else if p == 0 {
+		return 0
+	} else if p == 1 {
+		return math.Inf(1)
+	}
+	y := statutil.IncBetaInv(f.d2/2, f.d1/2, 1-p)
+	return f.d2 * (1 - y) / (f.d1 * y)
+}
+
+// PDF returns the probability density at x.
+func (f *F) PDF(x float64) float64 {
+	if x < 0 {
+		return 0
+	} else if x == 0 {
+		switch {
+		case f.d1 < 2:
+			return math.Inf(1)
+		case f.d1 == 2:
+			return 1
+		default:
+			return 0
+		}
+	}
+	return math.Exp(f.LogPDF(x))
+}
+
+func (f *F) LogPDF(x float64) float64 {
+	if x < 0 || (x == 0 && f.d1 > 2) {
+		return math.Inf(-1)
+	} else if x == 0 {
+		return math.Log(f.PDF(0))
+	}
+	a, b := f.d1/2, f.d2/2
+	return a*math.Log(f.d1/f.d2) + (a-1)*math.Log(x) -
+		(a+b)*math.Log1p(f.d1*x/f.d2) - statutil.LogBeta(a, b)
+}
+
+// Mean returns d2/(d2-2) for d2 > 2, and +Inf otherwise.
+func (f *F) Mean() float64 {
+	if f.d2 <= 2 {
+		return math.Inf(1)
+	}
+	return f.d2 / (f.d2 - 2)
+}
+
+// Var returns the variance for d2 > 4, +Inf for 2 < d2 ≤ 4, and NaN otherwise.
+func (f *F) Var() float64 {
+	d1, d2 := f.d1, f.d2
+	switch {
+	case d2 > 4:
+		return 2 * d2 * d2 * (d1 + d2 - 2) / (d1 * (d2 - 2) * (d2 - 2) * (d2 - 4))
+	case d2 > 2:
+		return math.Inf(1)
+	default:
+		return math.NaN()
+	}
+}
+
+func (f *F) Std() float64 { return math.Sqrt(f.Var()) }
+
+// Skewness returns the skewness for d2 > 6, and NaN otherwise.
+func (f *F) Skewness() float64 {
+	d1, d2 := f.d1, f.d2
+	if d2 <= 6 {
+		return math.NaN()
+	}
+	return (2*d1 + d2 - 2) * math.Sqrt(8*(d2-4)) / ((d2 - 6) * math.Sqrt(d1*(d1+d2-2)))
+}
+
+// ExcessKurtosis returns the excess kurtosis for d2 > 8, and NaN otherwise.
+func (f *F) ExcessKurtosis() float64 {
+	d1, d2 := f.d1, f.d2
+	if d2 <= 8 {
+		return math.NaN()
+	}
+	num := d1*(5*d2-22)*(d1+d2-2) + (d2-4)*(d2-2)*(d2-2)
+	return 12 * num / (d1 * (d2 - 6) * (d2 - 8) * (d1 + d2 - 2))
+}
+
+// Moment returns the n-th raw moment, which is finite for 2n < d2.
+// It is (d2/d1)^n Π (d1+2i)/(d2-2i-2) for i from 0 to n-1.
+// As the values are positive, the moments for 2n >= d2 are +Inf.
+func (f *F) Moment(n int) float64 {
+	if n == 0 {
+		return 1
+	} else if n < 0 {
+		return math.NaN()
+	} else if 2*float64(n) >= f.d2 {
+		return math.Inf(1)
+	}
+	m := 1.0
+	for i := 0; i < n; i++ {
+		m *= f.d2 / f.d1 * (f.d1 + 2*float64(i)) / (f.d2 - 2*float64(i) - 2)
+	}
+	return m
+}
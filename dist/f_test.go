@@ -0,0 +1,61 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/goulash/stat/statutil"
+)
+
+func TestF(z *testing.T) {
+	// The square of a t-distributed value is F-distributed with 1 and nu
+	// degrees of freedom.
+	for _, nu := range []float64{1, 4, 10, 30} {
+		f := NewF(nil, 1, nu)
+		for _, p := range []float64{0.1, 0.5, 0.9, 0.95, 0.99} {
+			t := statutil.StudentTQuantile((1+p)/2, nu)
+			if x := f.Q(p); math.Abs(x-t*t) > 1e-9*t*t {
+				z.Errorf("F(1, %v).Q(%v) = %v, want %v", nu, p, x, t*t)
+			}
+			if q := f.P(t * t); math.Abs(q-p) > 1e-12 {
+				z.Errorf("F(1, %v).P(%v) = %v, want %v", nu, t*t, q, p)
+			}
+		}
+	}
+
+	f := NewF(nil, 5, 20)
+	if s := f.Survival(50); s <= 0 || s > 1e-9 || math.Abs(s-(1-f.P(50))) > 1e-15 {
+		z.Errorf("F(5, 20).Survival(50) = %v, want a small positive value", s)
+	}
+
+	// The second moment agrees with the variance, also where both diverge.
+	for _, d2 := range []float64{1.5, 3, 4, 9} {
+		f := NewF(nil, 5, d2)
+		m1, m2 := f.Moment(1), f.Moment(2)
+		if v := f.Var(); d2 > 4 && math.Abs(m2-m1*m1-v) > 1e-12*v {
+			z.Errorf("F(5, %v).Moment(2) = %v, want Var() + Mean()² = %v", d2, m2, v+m1*m1)
+		} else if d2 <= 4 && !math.IsInf(m2, 1) {
+			z.Errorf("F(5, %v).Moment(2) = %v, want +Inf", d2, m2)
+		} else if d2 > 2 && d2 <= 4 && !math.IsInf(v, 1) {
+			z.Errorf("F(5, %v).Var() = %v, want +Inf", d2, v)
+		}
+		if m1 != f.Mean() {
+			z.Errorf("F(5, %v).Moment(1) = %v, want Mean() = %v", d2, m1, f.Mean())
+		}
+	}
+
+	f = NewF(rand.NewSource(1), 5, 20)
+	var m float64
+	const n = 100000
+	for i := 0; i < n; i++ {
+		m += f.Float64()
+	}
+	if m /= n; math.Abs(m-f.Mean()) > 0.02 {
+		z.Errorf("F(5, 20) sample mean = %v, want %v", m, f.Mean())
+	}
+}
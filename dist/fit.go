@@ -0,0 +1,285 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/goulash/stat"
+)
+
+var (
+	// ErrTooFewValues is returned when there are not enough values
+	// to estimate the parameters of a distribution.
+	ErrTooFewValues = errors.New("too few values to fit distribution")
+
+	// ErrOutOfSupport is returned when a value lies outside the support
+	// of the distribution that should be fitted.
+	ErrOutOfSupport = errors.New("value outside of the support of the distribution")
+
+	// ErrDegenerate is returned when the values have no spread, so that
+	// the fitted distribution would be degenerate.
+	ErrDegenerate = errors.New("values are all the same")
+)
+
+// The Fit* functions return the maximum likelihood estimate of a distribution
+// for the values xs, together with its log-likelihood. The random source s
+// is passed on to the distribution, so that it is ready to be sampled.
+
+// FitExponential fits an exponential distribution, with λ = 1/mean.
+// All values must be non-negative.
+func FitExponential(s rand.Source, xs stat.Series) (*Exponential, float64, error) {
+	if len(xs) == 0 {
+		return nil, 0, ErrTooFewValues
+	}
+	if xs.Min() < 0 {
+		return nil, 0, ErrOutOfSupport
+	}
+	m := xs.Mean()
+	if m == 0 {
+		return nil, 0, ErrDegenerate
+	}
+
+	e := NewExponential(s, 1/m)
+	return e, LogLikelihood(e, xs), nil
+}
+
+// FitNormal fits a normal distribution, with the mean and the population
+// standard deviation of xs.
+func FitNormal(s rand.Source, xs stat.Series) (*Normal, float64, error) {
+	if len(xs) < 2 {
+		return nil, 0, ErrTooFewValues
+	}
+	std := xs.StdP()
+	if std == 0 {
+		return nil, 0, ErrDegenerate
+	}
+
+	n := NewNormal(s, xs.Mean(), std)
+	return n, LogLikelihood(n, xs), nil
+}
+
+// FitLogNormal fits a log-normal distribution, with μ and σ being the mean
+// and population standard deviation of the logarithms of xs.
+// All values must be positive.
+func FitLogNormal(s rand.Source, xs stat.Series) (*LogNormal, float64, error) {
+	if len(xs) < 2 {
+		return nil, 0, ErrTooFewValues
+	}
+	if xs.Min() <= 0 {
+		return nil, 0, ErrOutOfSupport
+	}
+	ls := xs.Map(math.Log)
+	sigma := ls.StdP()
+	if sigma == 0 {
+		return nil, 0, ErrDegenerate
+	}
+
+	n := NewLogNormalMuSigma(s, ls.Mean(), sigma)
+	return n, LogLikelihood(n, xs), nil
+}
+
+// FitPoisson fits a poisson distribution, with λ = mean.
+// All values must be non-negative integers.
+func FitPoisson(s rand.Source, xs stat.Series) (*Poisson, float64, error) {
+	if len(xs) == 0 {
+		return nil, 0, ErrTooFewValues
+	}
+	for _, x := range xs {
+		if x < 0 || x != math.Trunc(x) {
+			return nil, 0, ErrOutOfSupport
+		}
+	}
+	m := xs.Mean()
+	if m == 0 {
+		return nil, 0, ErrDegenerate
+	}
+
+	p := NewPoisson(s, m)
+	var ll float64
+	for _, x := range xs {
+		ll += math.Log(p.PMF(int64(x)))
+	}
+	return p, ll, nil
+}
+
+// FitHyperExponential fits a hyper-exponential distribution with k phases
+// using the expectation-maximization algorithm. All values must be
+// non-negative.
+//
+// The phases are initialised by splitting the sorted values into k groups
+// of equal size, after which the algorithm iterates until the log-likelihood
+// converges. Like all EM algorithms, it finds a local maximum.
+func FitHyperExponential(s rand.Source, xs stat.Series, k int) (*HyperExponential, float64, error) {
+	if k < 1 {
+		panic("number of phases must be positive")
+	}
+	n := len(xs)
+	if n < 2*k {
+		return nil, 0, ErrTooFewValues
+	}
+	if xs.Min() < 0 {
+		return nil, 0, ErrOutOfSupport
+	}
+	if xs.Max() == xs.Min() {
+		return nil, 0, ErrDegenerate
+	}
+
+	// Initialise each phase with a group of the sorted values.
+	sorted := xs.Copy()
+	sort.Float64s(sorted)
+	ps := make([]float64, k)
+	ls := make([]float64, k)
+	for j := range ls {
+		g := sorted[j*n/k : (j+1)*n/k]
+		ps[j] = 1 / float64(k)
+		ls[j] = 1 / math.Max(g.Mean(), 1e-12*sorted[n-1])
+	}
+
+	ws := make([]float64, k) // responsibilities of the phases for one value
+	wsum := make([]float64, k)
+	wxsum := make([]float64, k)
+	ll := math.Inf(-1)
+	for iter := 0; iter < 10000; iter++ {
+		for j := range wsum {
+			wsum[j], wxsum[j] = 0, 0
+		}
+
+		// E-step: compute the responsibilities in log space, so that
+		// values far in the tail do not underflow.
+		var next float64
+		for _, x := range xs {
+			max := math.Inf(-1)
+			for j := range ws {
+				ws[j] = math.Log(ps[j]) + math.Log(ls[j]) - ls[j]*x
+				max = math.Max(max, ws[j])
+			}
+			var sum float64
+			for j := range ws {
+				ws[j] = math.Exp(ws[j] - max)
+				sum += ws[j]
+			}
+			next += max + math.Log(sum)
+			for j := range ws {
+				w := ws[j] / sum
+				wsum[j] += w
+				wxsum[j] += w * x
+			}
+		}
+
+		// M-step: update the probabilities and rates of the phases.
+		for j := range ps {
+			ps[j] = wsum[j] / float64(n)
+			if wsum[j] > 0 && wxsum[j] > 0 {
+				ls[j] = wsum[j] / wxsum[j]
+			}
+		}
+
+		if next-ll <= 1e-10*math.Abs(next) {
+			ll = next
+			break
+		}
+		ll = next
+	}
+
+	// NewHyperExponential expects cumulative probabilities.
+	cum := make([]float64, k)
+	var c float64
+	for j, p := range ps {
+		c += p
+		cum[j] = c
+	}
+	h := NewHyperExponential(s, cum, ls)
+	return h, LogLikelihood(h, xs), nil
+}
+
+// FittedDist is a continuous distribution that can be fitted by FitBest.
+type FittedDist interface {
+	Continuous
+	Density
+	String() string
+}
+
+// Fit is the result of fitting a distribution to a series of values.
+type Fit struct {
+	Dist          FittedDist
+	LogLikelihood float64
+	Params        int // number of estimated parameters
+	N             int // number of values
+}
+
+// AIC returns the Akaike information criterion, 2k - 2 log L.
+// Lower is better.
+func (f Fit) AIC() float64 {
+	return 2*float64(f.Params) - 2*f.LogLikelihood
+}
+
+// BIC returns the Bayesian information criterion, k log n - 2 log L.
+// Lower is better; it penalizes parameters more strongly than AIC.
+func (f Fit) BIC() float64 {
+	return float64(f.Params)*math.Log(float64(f.N)) - 2*f.LogLikelihood
+}
+
+// Criterion selects how FitBest ranks the fitted distributions.
+type Criterion int
+
+const (
+	AIC Criterion = iota
+	BIC
+)
+
+func (c Criterion) score(f Fit) float64 {
+	if c == BIC {
+		return f.BIC()
+	}
+	return f.AIC()
+}
+
+// FitBest fits all the continuous distributions that apply to xs and
+// returns them ranked by the criterion c, best first.
+//
+// The candidates are the normal distribution, and for non-negative values
+// the exponential and the two-phase hyper-exponential distribution, and for
+// positive values the log-normal distribution. The poisson distribution
+// is not a candidate, since a likelihood computed from probabilities
+// cannot be compared with one computed from densities.
+//
+// If no distribution can be fitted, the error of the normal fit is returned.
+func FitBest(s rand.Source, xs stat.Series, c Criterion) ([]Fit, error) {
+	var fits []Fit
+	add := func(d FittedDist, ll float64, k int) {
+		if !math.IsNaN(ll) && !math.IsInf(ll, 0) {
+			fits = append(fits, Fit{d, ll, k, len(xs)})
+		}
+	}
+
+	n, ll, err := FitNormal(s, xs)
+	if err == nil {
+		add(n, ll, 2)
+	}
+	if e, ll, err := FitExponential(s, xs); err == nil {
+		add(e, ll, 1)
+	}
+	if l, ll, err := FitLogNormal(s, xs); err == nil {
+		add(l, ll, 2)
+	}
+	if h, ll, err := FitHyperExponential(s, xs, 2); err == nil {
+		add(h, ll, 3)
+	}
+	if len(fits) == 0 {
+		if err == nil {
+			err = ErrDegenerate
+		}
+		return nil, err
+	}
+
+	sort.SliceStable(fits, func(i, j int) bool {
+		return c.score(fits[i]) < c.score(fits[j])
+	})
+	return fits, nil
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/goulash/stat"
+)
+
+func sample(d Continuous, n int) stat.Series {
+	xs := make(stat.Series, n)
+	for i := range xs {
+		xs[i] = d.Float64()
+	}
+	return xs
+}
+
+func TestFit(z *testing.T) {
+	src := rand.NewSource(1)
+	const n = 20000
+
+	xs := sample(NewExponential(src, 2.5), n)
+	e, ll, err := FitExponential(src, xs)
+	if err != nil {
+		z.Fatal(err)
+	}
+	if math.Abs(e.Mean()-0.4) > 0.02 {
+		z.Errorf("FitExponential: mean = %v, want 0.4", e.Mean())
+	}
+	if want := float64(n)*math.Log(1/xs.Mean()) - float64(n); math.Abs(ll-want) > 1e-6*math.Abs(want) {
+		z.Errorf("FitExponential: log-likelihood = %v, want %v", ll, want)
+	}
+
+	xs = sample(NewNormal(src, -3, 2), n)
+	nd, _, err := FitNormal(src, xs)
+	if err != nil {
+		z.Fatal(err)
+	}
+	if math.Abs(nd.Mean()+3) > 0.05 || math.Abs(nd.Std()-2) > 0.05 {
+		z.Errorf("FitNormal: got mean %v, std %v, want -3, 2", nd.Mean(), nd.Std())
+	}
+
+	xs = sample(NewLogNormalMuSigma(src, 1, 0.5), n)
+	ld, _, err := FitLogNormal(src, xs)
+	if err != nil {
+		z.Fatal(err)
+	}
+	if math.Abs(ld.Mu()-1) > 0.02 || math.Abs(ld.Sigma()-0.5) > 0.02 {
+		z.Errorf("FitLogNormal: got mu %v, sigma %v, want 1, 0.5", ld.Mu(), ld.Sigma())
+	}
+
+	p := NewPoisson(src, 4)
+	xs = make(stat.Series, n)
+	for i := range xs {
+		xs[i] = float64(p.Int63())
+	}
+	pd, _, err := FitPoisson(src, xs)
+	if err != nil {
+		z.Fatal(err)
+	}
+	if math.Abs(pd.Mean()-4) > 0.1 {
+		z.Errorf("FitPoisson: mean = %v, want 4", pd.Mean())
+	}
+
+	// The maximum likelihood estimate must be at least as likely as the
+	// distribution the values came from.
+	h := NewHyperExponential(src, []float64{0.3, 1}, []float64{0.2, 5})
+	xs = sample(h, n)
+	hd, ll, err := FitHyperExponential(src, xs, 2)
+	if err != nil {
+		z.Fatal(err)
+	}
+	if want := LogLikelihood(h, xs); ll < want {
+		z.Errorf("FitHyperExponential: log-likelihood = %v, want at least %v", ll, want)
+	}
+	if math.Abs(hd.Mean()-h.Mean()) > 0.05*h.Mean() {
+		z.Errorf("FitHyperExponential: mean = %v, want %v", hd.Mean(), h.Mean())
+	}
+}
+
+func TestFitErrors(z *testing.T) {
+	src := rand.NewSource(1)
+	exponential := func(xs stat.Series) error { _, _, err := FitExponential(src, xs); return err }
+	normal := func(xs stat.Series) error { _, _, err := FitNormal(src, xs); return err }
+	lognormal := func(xs stat.Series) error { _, _, err := FitLogNormal(src, xs); return err }
+	poisson := func(xs stat.Series) error { _, _, err := FitPoisson(src, xs); return err }
+	hyper := func(xs stat.Series) error { _, _, err := FitHyperExponential(src, xs, 2); return err }
+
+	tests := []struct {
+		name string
+		fit  func(stat.Series) error
+		xs   stat.Series
+		err  error
+	}{
+		{"exponential", exponential, nil, ErrTooFewValues},
+		{"exponential", exponential, stat.Series{1, -1}, ErrOutOfSupport},
+		{"exponential", exponential, stat.Series{0, 0}, ErrDegenerate},
+		{"normal", normal, stat.Series{1}, ErrTooFewValues},
+		{"normal", normal, stat.Series{2, 2, 2}, ErrDegenerate},
+		{"lognormal", lognormal, stat.Series{1, 0}, ErrOutOfSupport},
+		{"poisson", poisson, stat.Series{1, 2.5}, ErrOutOfSupport},
+		{"hyperexponential", hyper, stat.Series{1, 2, 3}, ErrTooFewValues},
+		{"hyperexponential", hyper, stat.Series{1, 1, 1, 1}, ErrDegenerate},
+	}
+	for _, t := range tests {
+		if err := t.fit(t.xs); err != t.err {
+			z.Errorf("fit %s to %v: got error %v, want %v", t.name, t.xs, err, t.err)
+		}
+	}
+}
+
+func TestFitBest(z *testing.T) {
+	src := rand.NewSource(2)
+	tests := []struct {
+		d    Continuous
+		want string
+	}{
+		{NewNormal(src, 10, 1), "normal"},
+		{NewExponential(src, 2), "exponential"},
+		{NewLogNormalMuSigma(src, 0, 1), "lognormal"},
+	}
+
+	for _, t := range tests {
+		xs := sample(t.d, 5000)
+		for _, c := range []Criterion{AIC, BIC} {
+			fits, err := FitBest(src, xs, c)
+			if err != nil {
+				z.Fatal(err)
+			}
+			if got := fits[0].Dist.String(); !strings.HasPrefix(got, t.want) {
+				z.Errorf("FitBest(%v, %v) = %v, want %s", t.d, c, got, t.want)
+			}
+			for i := 1; i < len(fits); i++ {
+				if c.score(fits[i]) < c.score(fits[i-1]) {
+					z.Errorf("FitBest(%v, %v): fits not ranked", t.d, c)
+				}
+			}
+		}
+	}
+
+	if _, err := FitBest(src, nil, AIC); err != ErrTooFewValues {
+		z.Errorf("FitBest of no values: got error %v, want %v", err, ErrTooFewValues)
+	}
+}
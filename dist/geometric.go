@@ -0,0 +1,102 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// Geometric distribution with success probability p.
+//
+// This is the distribution of the number of failures before the first
+// success in a sequence of independent trials, so its support starts at 0.
+// It models, for example, the number of retries an operation needs.
+type Geometric struct {
+	r *rand.Rand
+	p float64
+}
+
+// NewGeometric returns a geometric distribution with success probability p,
+// where p is in (0, 1].
+//
+// The random source s is only used for Int63, so if the distribution is
+// not sampled, s may be nil.
+func NewGeometric(s rand.Source, p float64) *Geometric {
+	if !(0 < p && p <= 1) {
+		panic("p must be in (0, 1]")
+	}
+
+	g := &Geometric{p: p}
+	if s != nil {
+		g.r = rand.New(s)
+	}
+	return g
+}
+
+func (g *Geometric) String() string {
+	return fmt.Sprintf("geometric [%v]", g.p)
+}
+
+// Int63 returns a random number of failures, using the inversion method.
+func (g *Geometric) Int63() int64 {
+	if g.p == 1 {
+		return 0
+	}
+	u := 1 - g.r.Float64() // in (0, 1]
+	return int64(math.Floor(math.Log(u) / math.Log1p(-g.p)))
+}
+
+// PMF returns the probability of exactly k failures.
+func (g *Geometric) PMF(k int64) float64 {
+	if k < 0 {
+		return 0
+	} else if k == 0 {
+		// Avoid 0·log(0) if p is 1.
+		return g.p
+	}
+	return g.p * math.Exp(float64(k)*math.Log1p(-g.p))
+}
+
+// CDF returns the probability of at most k failures.
+func (g *Geometric) CDF(k int64) float64 {
+	if k < 0 {
+		return 0
+	}
+	return -math.Expm1(float64(k+1) * math.Log1p(-g.p))
+}
+
+// Quantile returns the smallest k such that CDF(k) >= p.
+func (g *Geometric) Quantile(p float64) int64 {
+	return searchUnboundedQuantile(g.CDF, p, int64(math.Ceil(g.Mean())))
+}
+
+func (g *Geometric) Mean() float64 { return (1 - g.p) / g.p }
+func (g *Geometric) Var() float64  { return (1 - g.p) / (g.p * g.p) }
+func (g *Geometric) Std() float64  { return math.Sqrt(g.Var()) }
+
+func (g *Geometric) Skewness() float64 {
+	if g.p == 1 {
+		return math.NaN()
+	}
+	return (2 - g.p) / math.Sqrt(1-g.p)
+}
+
+func (g *Geometric) ExcessKurtosis() float64 {
+	if g.p == 1 {
+		return math.NaN()
+	}
+	return 6 + g.p*g.p/(1-g.p)
+}
+
+// Moment returns the n-th raw moment, computed from the factorial
+// moments j! ((1-p)/p)^j.
+func (g *Geometric) Moment(n int) float64 {
+	q := (1 - g.p) / g.p
+	return factorialMoments(n, func(j int) float64 {
+		return math.Gamma(float64(j+1)) * math.Pow(q, float64(j))
+	})
+}
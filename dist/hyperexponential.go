@@ -6,6 +6,7 @@ package dist
 
 import (
 	"fmt"
+	"math"
 	"math/rand"
 )
 
@@ -45,6 +46,41 @@ func (e *HyperExponential) Float64() float64 {
 	return e.r.ExpFloat64() / e.lambdas[e.stairs.Int63()]
 }
 
+// PDF returns the probability density at x, Σ p_i λ_i exp(-λ_i x).
+func (e *HyperExponential) PDF(x float64) float64 {
+	if x < 0 {
+		return 0
+	}
+	var d, prev float64
+	for i, p := range e.stairs.p {
+		d += (p - prev) * e.lambdas[i] * math.Exp(-e.lambdas[i]*x)
+		prev = p
+	}
+	return d
+}
+
+// LogPDF returns the logarithm of the density, which is computed with
+// the log-sum-exp trick, so that it is accurate far into the tail.
+func (e *HyperExponential) LogPDF(x float64) float64 {
+	if x < 0 {
+		return math.Inf(-1)
+	}
+
+	ls := make([]float64, len(e.lambdas))
+	max := math.Inf(-1)
+	var prev float64
+	for i, p := range e.stairs.p {
+		ls[i] = math.Log(p-prev) + math.Log(e.lambdas[i]) - e.lambdas[i]*x
+		max = math.Max(max, ls[i])
+		prev = p
+	}
+	var sum float64
+	for _, l := range ls {
+		sum += math.Exp(l - max)
+	}
+	return max + math.Log(sum)
+}
+
 func (e *HyperExponential) Mean() float64 {
 	var mean, prev float64
 	for i, p := range e.stairs.p {
@@ -67,3 +103,30 @@ func (e *HyperExponential) SecondMoment() float64 {
 func (e *HyperExponential) Var() float64 {
 	return e.SecondMoment() - (e.Mean() * e.Mean())
 }
+
+func (e *HyperExponential) Std() float64 { return math.Sqrt(e.Var()) }
+
+func (e *HyperExponential) Skewness() float64 {
+	s, _ := shape(e.Moment(1), e.Moment(2), e.Moment(3), e.Moment(4))
+	return s
+}
+
+func (e *HyperExponential) ExcessKurtosis() float64 {
+	_, k := shape(e.Moment(1), e.Moment(2), e.Moment(3), e.Moment(4))
+	return k
+}
+
+// Moment returns the n-th raw moment, which is the mixture of the
+// moments n!/λ^n of the individual exponential distributions.
+func (e *HyperExponential) Moment(n int) float64 {
+	if n < 0 {
+		return math.NaN()
+	}
+	f := math.Gamma(float64(n + 1))
+	var m, prev float64
+	for i, p := range e.stairs.p {
+		m += (p - prev) * f / math.Pow(e.lambdas[i], float64(n))
+		prev = p
+	}
+	return m
+}
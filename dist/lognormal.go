@@ -8,42 +8,137 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+
+	"github.com/goulash/stat/statutil"
 )
 
-// LogNormal distribution with mean and standard deviation.
+// LogNormal distribution, whose logarithm is normally distributed.
+//
+// There are two common ways to parameterise a log-normal distribution:
+// by the mean μ and standard deviation σ of the underlying normal
+// distribution, see NewLogNormalMuSigma, or by the mean and standard
+// deviation of the log-normal variable itself, see NewLogNormal.
+// The methods Mean, Var, and Std always refer to the log-normal variable,
+// and Mu and Sigma to the underlying normal distribution.
 //
 // See:
 //  http://stackoverflow.com/questions/23699738
 //  http://blogs.sas.com/content/iml/2014/06/04/simulate-lognormal-data-with-specified-mean-and-variance.html
 type LogNormal struct {
-	r    *rand.Rand
-	mean float64
-	std  float64
+	r     *rand.Rand
+	mu    float64
+	sigma float64
 }
 
+// NewLogNormal returns a log-normal distribution with mean m and
+// standard deviation s, where m and s are positive.
 func NewLogNormal(rs rand.Source, m, s float64) *LogNormal {
-	if rs == nil {
-		panic("rs cannot be nil")
+	if !(m > 0 && s > 0) {
+		panic("mean and standard deviation must be positive")
 	}
 
-	// Scale mean and std down to expected mean and std
+	// Scale mean and std down to the parameters of the underlying normal.
 	m2, s2 := m*m, s*s
 	mu := math.Log((m * m) / math.Sqrt(m2+s2))
 	sigma := math.Sqrt(math.Log((m2 + s2) / m2))
+	return NewLogNormalMuSigma(rs, mu, sigma)
+}
+
+// NewLogNormalMuSigma returns a log-normal distribution whose logarithm
+// has mean mu and standard deviation sigma, where sigma is positive.
+func NewLogNormalMuSigma(rs rand.Source, mu, sigma float64) *LogNormal {
+	if rs == nil {
+		panic("rs cannot be nil")
+	}
+	if !(sigma > 0) {
+		panic("sigma must be positive")
+	}
 
 	return &LogNormal{rand.New(rs), mu, sigma}
 }
 
 func (n *LogNormal) String() string {
-	return fmt.Sprintf("lognormal [μ=%f σ=%f]", n.mean, n.std)
+	return fmt.Sprintf("lognormal [μ=%f σ=%f]", n.mu, n.sigma)
 }
 
 func (n *LogNormal) Float64() float64 {
-	return math.Exp(n.r.NormFloat64()*n.std + n.mean)
+	return math.Exp(n.r.NormFloat64()*n.sigma + n.mu)
 }
 
-func (n *LogNormal) Mean() float64 { return n.mean }
-func (n *LogNormal) Var() float64  { return n.std * n.std }
-func (n *LogNormal) Std() float64  { return n.std }
+func (n *LogNormal) P(x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	return statutil.NormalCDF(n.Z(x))
+}
+
+func (n *LogNormal) Q(p float64) float64 {
+	if !isProb(p) {
+		return math.NaN()
+	} else if p == 0 {
+		return 0
+	} else if p == 1 {
+		return math.Inf(1)
+	}
+	return math.Exp(n.mu + n.sigma*statutil.NormalQuantile(p))
+}
+
+// PDF returns the probability density at x.
+func (n *LogNormal) PDF(x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	z := n.Z(x)
+	return math.Exp(-z*z/2) / (x * n.sigma * math.Sqrt(2*math.Pi))
+}
+
+func (n *LogNormal) LogPDF(x float64) float64 {
+	if x <= 0 {
+		return math.Inf(-1)
+	}
+	z := n.Z(x)
+	return -z*z/2 - math.Log(x*n.sigma) - 0.5*math.Log(2*math.Pi)
+}
+
+// Mu returns the mean of the underlying normal distribution.
+func (n *LogNormal) Mu() float64 { return n.mu }
+
+// Sigma returns the standard deviation of the underlying normal distribution.
+func (n *LogNormal) Sigma() float64 { return n.sigma }
+
+// Mean returns the mean of the log-normal variable, exp(μ + σ²/2).
+func (n *LogNormal) Mean() float64 {
+	return math.Exp(n.mu + n.sigma*n.sigma/2)
+}
+
+// Var returns the variance of the log-normal variable, (exp(σ²) - 1)·exp(2μ + σ²).
+func (n *LogNormal) Var() float64 {
+	s2 := n.sigma * n.sigma
+	return math.Expm1(s2) * math.Exp(2*n.mu+s2)
+}
+
+// Std returns the standard deviation of the log-normal variable.
+func (n *LogNormal) Std() float64 { return math.Sqrt(n.Var()) }
+
+func (n *LogNormal) Skewness() float64 {
+	e := math.Exp(n.sigma * n.sigma)
+	return (e + 2) * math.Sqrt(e-1)
+}
+
+func (n *LogNormal) ExcessKurtosis() float64 {
+	s2 := n.sigma * n.sigma
+	return math.Exp(4*s2) + 2*math.Exp(3*s2) + 3*math.Exp(2*s2) - 6
+}
+
+// Moment returns the k-th raw moment, exp(kμ + k²σ²/2).
+func (n *LogNormal) Moment(k int) float64 {
+	if k < 0 {
+		return math.NaN()
+	}
+	f := float64(k)
+	return math.Exp(f*n.mu + f*f*n.sigma*n.sigma/2)
+}
 
-func (n *LogNormal) Z(x float64) float64 { return (x - n.mean) / n.std }
+// Z returns the standard score of log(x) in the underlying normal
+// distribution, so that P(x) is the standard normal CDF of Z(x).
+func (n *LogNormal) Z(x float64) float64 { return (math.Log(x) - n.mu) / n.sigma }
@@ -0,0 +1,93 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package dist
+
+import "math"
+
+// Moments is implemented by distributions whose moments are known analytically.
+type Moments interface {
+	Mean() float64
+	Var() float64
+	Std() float64
+
+	// Skewness returns the third standardized moment.
+	Skewness() float64
+
+	// ExcessKurtosis returns the fourth standardized moment minus 3,
+	// so that the normal distribution has an excess kurtosis of zero.
+	ExcessKurtosis() float64
+
+	// Moment returns the n-th raw moment E[X^n], where n >= 0.
+	// If the moment is infinite, +Inf is returned.
+	Moment(n int) float64
+}
+
+// CV returns the coefficient of variation of m, which is the ratio of
+// the standard deviation to the mean.
+func CV(m Moments) float64 {
+	return m.Std() / m.Mean()
+}
+
+// shape returns the skewness and excess kurtosis given the first four
+// raw moments.
+func shape(m1, m2, m3, m4 float64) (skew, kurt float64) {
+	v := m2 - m1*m1
+	c3 := m3 - 3*m1*m2 + 2*m1*m1*m1
+	c4 := m4 - 4*m1*m3 + 6*m1*m1*m2 - 3*m1*m1*m1*m1
+	return c3 / math.Pow(v, 1.5), c4/(v*v) - 3
+}
+
+// choose returns the binomial coefficient n over k as a float64.
+func choose(n, k int) float64 {
+	if k < 0 || k > n {
+		return 0
+	}
+	c := 1.0
+	for i := 1; i <= k; i++ {
+		c = c * float64(n-k+i) / float64(i)
+	}
+	return c
+}
+
+// factorialMoments returns the n-th raw moment given the factorial moments
+// f(j) = E[X(X-1)...(X-j+1)], using E[X^n] = Σ S(n, j) f(j), where S are
+// the Stirling numbers of the second kind.
+func factorialMoments(n int, f func(j int) float64) float64 {
+	if n < 0 {
+		return math.NaN()
+	} else if n == 0 {
+		return 1
+	}
+
+	// Compute the n-th row of the Stirling numbers of the second kind
+	// with the recurrence S(n, j) = j S(n-1, j) + S(n-1, j-1).
+	s := make([]float64, n+1)
+	s[0] = 1
+	for i := 1; i <= n; i++ {
+		for j := i; j >= 1; j-- {
+			s[j] = float64(j)*s[j] + s[j-1]
+		}
+		s[0] = 0
+	}
+
+	var m float64
+	for j := 1; j <= n; j++ {
+		m += s[j] * f(j)
+	}
+	return m
+}
+
+// rawMoment returns the n-th raw moment of a discrete distribution with
+// the given PMF over the finite support [lo, hi].
+func rawMoment(n int, pmf func(int64) float64, lo, hi int64) float64 {
+	if n < 0 {
+		return math.NaN()
+	}
+	var m float64
+	for k := lo; k <= hi; k++ {
+		m += math.Pow(float64(k), float64(n)) * pmf(k)
+	}
+	return m
+}
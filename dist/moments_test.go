@@ -0,0 +1,75 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestMoments(z *testing.T) {
+	src := rand.NewSource(0)
+	tests := []Moments{
+		NewUniform(src, -1, 3),
+		NewExponential(src, 2.5),
+		NewHyperExponential(src, []float64{0.3, 1}, []float64{1, 4}),
+		NewNormal(src, 2, 3),
+		NewLogNormalMuSigma(src, 0.5, 0.4),
+		NewChiSquared(src, 5),
+		NewStudentT(src, 9),
+		NewF(src, 5, 20),
+		NewPoisson(src, 3.5),
+		NewBinomial(src, 12, 0.3),
+		NewGeometric(src, 0.4),
+		NewNegativeBinomial(src, 2.5, 0.6),
+		NewStairs(src, 0.0, 0.3, 0.6, 0.6, 0.9),
+		NewUniformDiscrete(src, -3, 8),
+	}
+
+	near := func(a, b float64) bool {
+		return math.Abs(a-b) <= 1e-9*math.Max(1, math.Abs(b))
+	}
+	for _, d := range tests {
+		m1, m2, m3, m4 := d.Moment(1), d.Moment(2), d.Moment(3), d.Moment(4)
+		skew, kurt := shape(m1, m2, m3, m4)
+		if d.Moment(0) != 1 {
+			z.Errorf("%v.Moment(0) = %v, want 1", d, d.Moment(0))
+		}
+		if !near(d.Mean(), m1) {
+			z.Errorf("%v.Mean() = %v, want %v", d, d.Mean(), m1)
+		}
+		if !near(d.Var(), m2-m1*m1) {
+			z.Errorf("%v.Var() = %v, want %v", d, d.Var(), m2-m1*m1)
+		}
+		if !near(d.Std(), math.Sqrt(d.Var())) {
+			z.Errorf("%v.Std() = %v, want %v", d, d.Std(), math.Sqrt(d.Var()))
+		}
+		if !near(d.Skewness(), skew) {
+			z.Errorf("%v.Skewness() = %v, want %v", d, d.Skewness(), skew)
+		}
+		if !near(d.ExcessKurtosis(), kurt) {
+			z.Errorf("%v.ExcessKurtosis() = %v, want %v", d, d.ExcessKurtosis(), kurt)
+		}
+
+		// Discrete distributions can be checked against their PMF.
+		if dd, ok := d.(DiscreteDist); ok {
+			lo := dd.Quantile(0)
+			hi := dd.Quantile(1 - 1e-15)
+			if hi == math.MaxInt64 {
+				hi = 1000
+			}
+			for n := 1; n <= 5; n++ {
+				if m := rawMoment(n, dd.PMF, lo, hi); !near(d.Moment(n), m) {
+					z.Errorf("%v.Moment(%d) = %v, want %v", d, n, d.Moment(n), m)
+				}
+			}
+		}
+	}
+
+	if cv := CV(NewExponential(src, 3)); cv != 1 {
+		z.Errorf("coefficient of variation of exponential = %v, want 1", cv)
+	}
+}
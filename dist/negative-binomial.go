@@ -0,0 +1,122 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/goulash/stat/statutil"
+)
+
+// NegativeBinomial distribution with parameters r and p.
+//
+// This is the distribution of the number of failures before the r-th
+// success in a sequence of independent trials with success probability p.
+// The geometric distribution is the special case r = 1. The parameter r
+// need not be an integer, in which case this is also known as the Pólya
+// distribution, a common model for overdispersed counts.
+type NegativeBinomial struct {
+	rnd *rand.Rand
+	r   float64
+	p   float64
+}
+
+// NewNegativeBinomial returns a negative binomial distribution, where
+// r is positive and p is in (0, 1].
+//
+// The random source s is only used for Int63, so if the distribution is
+// not sampled, s may be nil.
+func NewNegativeBinomial(s rand.Source, r, p float64) *NegativeBinomial {
+	if !(r > 0) {
+		panic("r must be positive")
+	}
+	if !(0 < p && p <= 1) {
+		panic("p must be in (0, 1]")
+	}
+
+	nb := &NegativeBinomial{r: r, p: p}
+	if s != nil {
+		nb.rnd = rand.New(s)
+	}
+	return nb
+}
+
+func (nb *NegativeBinomial) String() string {
+	return fmt.Sprintf("negative binomial [%v %v]", nb.r, nb.p)
+}
+
+// Int63 returns a random number of failures.
+//
+// This uses that the negative binomial distribution is a poisson
+// distribution whose mean is gamma distributed.
+func (nb *NegativeBinomial) Int63() int64 {
+	if nb.p == 1 {
+		return 0
+	}
+	lambda := gammaFloat64(nb.rnd, nb.r) * (1 - nb.p) / nb.p
+	return poissonInt63(nb.rnd, lambda)
+}
+
+// PMF returns the probability of exactly k failures.
+func (nb *NegativeBinomial) PMF(k int64) float64 {
+	switch {
+	case k < 0:
+		return 0
+	case nb.p == 1:
+		return indicator(k == 0)
+	}
+	f := float64(k)
+	lc := statutil.LogGamma(f+nb.r) - statutil.LogGamma(f+1) - statutil.LogGamma(nb.r)
+	return math.Exp(lc + nb.r*math.Log(nb.p) + f*math.Log1p(-nb.p))
+}
+
+// CDF returns the probability of at most k failures.
+func (nb *NegativeBinomial) CDF(k int64) float64 {
+	switch {
+	case k < 0:
+		return 0
+	case nb.p == 1:
+		return 1
+	}
+	return statutil.IncBeta(nb.r, float64(k+1), nb.p)
+}
+
+// Quantile returns the smallest k such that CDF(k) >= p.
+func (nb *NegativeBinomial) Quantile(p float64) int64 {
+	return searchUnboundedQuantile(nb.CDF, p, int64(math.Ceil(nb.Mean())))
+}
+
+func (nb *NegativeBinomial) Mean() float64 { return nb.r * (1 - nb.p) / nb.p }
+func (nb *NegativeBinomial) Var() float64  { return nb.r * (1 - nb.p) / (nb.p * nb.p) }
+func (nb *NegativeBinomial) Std() float64  { return math.Sqrt(nb.Var()) }
+
+func (nb *NegativeBinomial) Skewness() float64 {
+	if nb.p == 1 {
+		return math.NaN()
+	}
+	return (2 - nb.p) / math.Sqrt((1-nb.p)*nb.r)
+}
+
+func (nb *NegativeBinomial) ExcessKurtosis() float64 {
+	if nb.p == 1 {
+		return math.NaN()
+	}
+	return 6/nb.r + nb.p*nb.p/((1-nb.p)*nb.r)
+}
+
+// Moment returns the n-th raw moment, computed from the factorial
+// moments r(r+1)...(r+j-1) ((1-p)/p)^j.
+func (nb *NegativeBinomial) Moment(n int) float64 {
+	q := (1 - nb.p) / nb.p
+	return factorialMoments(n, func(j int) float64 {
+		m := 1.0
+		for i := 0; i < j; i++ {
+			m *= (nb.r + float64(i)) * q
+		}
+		return m
+	})
+}
@@ -6,7 +6,10 @@ package dist
 
 import (
 	"fmt"
+	"math"
 	"math/rand"
+
+	"github.com/goulash/stat/statutil"
 )
 
 // Normal distribution with mean and standard deviation.
@@ -32,8 +35,54 @@ func (n *Normal) Float64() float64 {
 	return n.r.NormFloat64()*n.std + n.mean
 }
 
+func (n *Normal) P(x float64) float64 {
+	return statutil.NormalCDF(n.Z(x))
+}
+
+func (n *Normal) Q(p float64) float64 {
+	if !isProb(p) {
+		return math.NaN()
+	} else if p == 0 {
+		return math.Inf(-1)
+	} else if p == 1 {
+		return math.Inf(1)
+	}
+	return n.mean + n.std*statutil.NormalQuantile(p)
+}
+
+// PDF returns the probability density at x.
+func (n *Normal) PDF(x float64) float64 {
+	z := n.Z(x)
+	return math.Exp(-z*z/2) / (n.std * math.Sqrt(2*math.Pi))
+}
+
+func (n *Normal) LogPDF(x float64) float64 {
+	z := n.Z(x)
+	return -z*z/2 - math.Log(n.std) - 0.5*math.Log(2*math.Pi)
+}
+
 func (n *Normal) Mean() float64 { return n.mean }
 func (n *Normal) Var() float64  { return n.std * n.std }
 func (n *Normal) Std() float64  { return n.std }
 
+func (n *Normal) Skewness() float64       { return 0 }
+func (n *Normal) ExcessKurtosis() float64 { return 0 }
+
+// Moment returns the k-th raw moment, Σ C(k, j) μ^(k-j) σ^j (j-1)!!,
+// where the sum is over even j.
+func (n *Normal) Moment(k int) float64 {
+	if k < 0 {
+		return math.NaN()
+	}
+	var m float64
+	df := 1.0 // (j-1)!!
+	for j := 0; j <= k; j += 2 {
+		if j > 0 {
+			df *= float64(j - 1)
+		}
+		m += choose(k, j) * math.Pow(n.mean, float64(k-j)) * math.Pow(n.std, float64(j)) * df
+	}
+	return m
+}
+
 func (n *Normal) Z(x float64) float64 { return (x - n.mean) / n.std }
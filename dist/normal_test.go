@@ -0,0 +1,107 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+var (
+	_ Dist = (*Normal)(nil)
+	_ Dist = (*LogNormal)(nil)
+)
+
+func TestNormal(z *testing.T) {
+	n := NewNormal(rand.NewSource(0), 10, 2)
+	tests := []struct {
+		X, P float64
+	}{
+		{10, 0.5},
+		{10 + 2*1.959963984540054, 0.975},
+		{10 - 2*1.959963984540054, 0.025},
+		{12, 0.8413447460685429},
+	}
+	for _, t := range tests {
+		if p := n.P(t.X); math.Abs(p-t.P) > 1e-12 {
+			z.Errorf("%v.P(%v) = %v, want %v", n, t.X, p, t.P)
+		}
+		if x := n.Q(t.P); math.Abs(x-t.X) > 1e-9 {
+			z.Errorf("%v.Q(%v) = %v, want %v", n, t.P, x, t.X)
+		}
+	}
+	if d := n.PDF(10); math.Abs(d-1/(2*math.Sqrt(2*math.Pi))) > 1e-15 {
+		z.Errorf("%v.PDF(10) = %v", n, d)
+	}
+}
+
+func TestLogNormalP(z *testing.T) {
+	n := NewLogNormal(rand.NewSource(0), 3, 1)
+	for _, p := range []float64{0.01, 0.25, 0.5, 0.9, 0.999} {
+		x := n.Q(p)
+		if got := n.P(x); math.Abs(got-p) > 1e-12 {
+			z.Errorf("%v.P(%v.Q(%v)) = %v", n, n, p, got)
+		}
+	}
+	if p := n.P(-1); p != 0 {
+		z.Errorf("%v.P(-1) = %v, want 0", n, p)
+	}
+
+	// The density integrates to the difference of the cumulative probabilities.
+	var sum float64
+	const a, b, steps = 1.0, 5.0, 100000
+	h := (b - a) / steps
+	for i := 0; i < steps; i++ {
+		sum += n.PDF(a+(float64(i)+0.5)*h) * h
+	}
+	if want := n.P(b) - n.P(a); math.Abs(sum-want) > 1e-9 {
+		z.Errorf("integral of %v.PDF over [%v, %v] = %v, want %v", n, a, b, sum, want)
+	}
+}
+
+func TestLogNormalMoments(z *testing.T) {
+	tests := []struct {
+		Mean, Std float64
+	}{
+		{1, 0.5},
+		{3, 1},
+		{10, 20},
+	}
+	for _, t := range tests {
+		n := NewLogNormal(rand.NewSource(1), t.Mean, t.Std)
+		if math.Abs(n.Mean()-t.Mean) > 1e-12*t.Mean {
+			z.Errorf("%v.Mean() = %v, want %v", n, n.Mean(), t.Mean)
+		}
+		if math.Abs(n.Std()-t.Std) > 1e-12*t.Std {
+			z.Errorf("%v.Std() = %v, want %v", n, n.Std(), t.Std)
+		}
+
+		m := NewLogNormalMuSigma(rand.NewSource(1), n.Mu(), n.Sigma())
+		if m.Mean() != n.Mean() || m.Var() != n.Var() {
+			z.Errorf("%v and %v should be the same distribution", n, m)
+		}
+
+		// The sample moments should agree with the analytic ones. Heavy
+		// tails make the sample variance converge slowly, so we compare
+		// the moments of the logarithm, which is normally distributed.
+		const count = 200000
+		var sum, logs, logs2 float64
+		for i := 0; i < count; i++ {
+			x := n.Float64()
+			sum += x
+			logs += math.Log(x)
+			logs2 += math.Log(x) * math.Log(x)
+		}
+		mean, lmean := sum/count, logs/count
+		lstd := math.Sqrt(logs2/count - lmean*lmean)
+		if math.Abs(mean-n.Mean()) > 0.05*n.Std() {
+			z.Errorf("%v sample mean = %v, want %v", n, mean, n.Mean())
+		}
+		if math.Abs(lmean-n.Mu()) > 0.01 || math.Abs(lstd-n.Sigma()) > 0.01 {
+			z.Errorf("%v sample log moments = (%v, %v), want (%v, %v)", n, lmean, lstd, n.Mu(), n.Sigma())
+		}
+	}
+}
@@ -4,6 +4,8 @@
 
 package dist
 
+import "math"
+
 type Null struct{}
 
 func NewNull() *Null            { return &Null{} }
@@ -13,3 +15,12 @@ func (n Null) Mean() float64    { return 0.0 }
 func (n Null) Var() float64     { return 0.0 }
 func (n Null) Std() float64     { return 0.0 }
 func (n Null) String() string   { return "null" }
+
+func (n Null) Skewness() float64       { return math.NaN() }
+func (n Null) ExcessKurtosis() float64 { return math.NaN() }
+func (n Null) Moment(k int) float64 {
+	if k < 0 {
+		return math.NaN()
+	}
+	return indicator(k == 0)
+}
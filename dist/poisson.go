@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+
+	"github.com/goulash/stat/statutil"
 )
 
 // Poisson returns random numbers according to a poisson distribution.
@@ -33,14 +35,29 @@ func (p Poisson) String() string {
 }
 
 func (p Poisson) Int63() int64 {
-	a := math.Exp(-p.lambda)
-	var b float64 = 1
-	var k int64 = -1
-	for b <= a {
-		b *= p.r.Float64()
-		k++
+	return poissonInt63(p.r, p.lambda)
+}
+
+// PMF returns the probability of exactly k arrivals.
+func (p Poisson) PMF(k int64) float64 {
+	if k < 0 {
+		return 0
+	}
+	f := float64(k)
+	return math.Exp(f*math.Log(p.lambda) - p.lambda - statutil.LogGamma(f+1))
+}
+
+// CDF returns the probability of at most k arrivals.
+func (p Poisson) CDF(k int64) float64 {
+	if k < 0 {
+		return 0
 	}
-	return k
+	return statutil.IncGammaQ(float64(k+1), p.lambda)
+}
+
+// Quantile returns the smallest k such that CDF(k) >= q.
+func (p Poisson) Quantile(q float64) int64 {
+	return searchUnboundedQuantile(p.CDF, q, int64(math.Ceil(p.lambda)))
 }
 
 func (p Poisson) Mean() float64 {
@@ -50,3 +67,15 @@ func (p Poisson) Mean() float64 {
 func (p Poisson) Var() float64 {
 	return p.lambda
 }
+
+func (p Poisson) Std() float64            { return math.Sqrt(p.lambda) }
+func (p Poisson) Skewness() float64       { return 1 / math.Sqrt(p.lambda) }
+func (p Poisson) ExcessKurtosis() float64 { return 1 / p.lambda }
+
+// Moment returns the n-th raw moment, which is the Touchard polynomial
+// of degree n in λ.
+func (p Poisson) Moment(n int) float64 {
+	return factorialMoments(n, func(j int) float64 {
+		return math.Pow(p.lambda, float64(j))
+	})
+}
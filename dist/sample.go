@@ -0,0 +1,104 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+)
+
+// gammaFloat64 returns a gamma distributed value with the given shape and
+// scale 1, using the method of Marsaglia and Tsang, "A Simple Method for
+// Generating Gamma Variables" (2000).
+//
+// Distributions created without a random source have a nil r, for which
+// gammaFloat64 panics.
+func gammaFloat64(r *rand.Rand, shape float64) float64 {
+	if r == nil {
+		panic("distribution has no random source")
+	} else if shape < 1 {
+		// Boost the shape and correct with a uniform power.
+		return gammaFloat64(r, shape+1) * math.Pow(r.Float64(), 1/shape)
+	}
+
+	d := shape - 1.0/3.0
+	c := 1 / math.Sqrt(9*d)
+	for {
+		x := r.NormFloat64()
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+		u := r.Float64()
+		if u < 1-0.0331*x*x*x*x {
+			return d * v
+		}
+		if math.Log(u) < 0.5*x*x+d*(1-v+math.Log(v)) {
+			return d * v
+		}
+	}
+}
+
+// betaFloat64 returns a beta distributed value with shapes a and b.
+func betaFloat64(r *rand.Rand, a, b float64) float64 {
+	x := gammaFloat64(r, a)
+	y := gammaFloat64(r, b)
+	return x / (x + y)
+}
+
+// binomialInt63 returns the number of successes in n trials with success
+// probability p.
+//
+// Large n are reduced in O(log n) steps with the method of Knuth, TAOCP
+// Vol. 2, §3.4.1: the a-th smallest of n uniform values is beta distributed,
+// and the number of values below p can then be counted recursively on
+// one side of it. The remaining trials are simulated directly.
+func binomialInt63(r *rand.Rand, n int64, p float64) int64 {
+	var k int64
+	for n > 40 {
+		a := 1 + n/2
+		b := n + 1 - a
+		x := betaFloat64(r, float64(a), float64(b))
+		if x >= p {
+			n, p = a-1, p/x
+		} else {
+			k += a
+			n, p = b-1, (p-x)/(1-x)
+		}
+	}
+	for i := int64(0); i < n; i++ {
+		if r.Float64() < p {
+			k++
+		}
+	}
+	return k
+}
+
+// poissonInt63 returns a poisson distributed value with mean lambda.
+//
+// Large lambda are reduced with the method of Knuth, TAOCP Vol. 2, §3.4.1,
+// which uses that the m-th arrival of a poisson process is gamma distributed.
+// The remainder is simulated by multiplying uniform values.
+func poissonInt63(r *rand.Rand, lambda float64) int64 {
+	var k int64
+	for lambda > 30 {
+		m := int64(lambda * 7 / 8)
+		x := gammaFloat64(r, float64(m))
+		if x >= lambda {
+			return k + binomialInt63(r, m-1, lambda/x)
+		}
+		k += m
+		lambda -= x
+	}
+
+	a := math.Exp(-lambda)
+	b := r.Float64()
+	for b > a {
+		b *= r.Float64()
+		k++
+	}
+	return k
+}
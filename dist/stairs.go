@@ -6,6 +6,7 @@ package dist
 
 import (
 	"fmt"
+	"math"
 	"math/rand"
 )
 
@@ -78,25 +79,49 @@ func (s *Stairs) Int63() int64 {
 	return s.z
 }
 
+// P returns the probability of index x.
+//
+// Deprecated: P is the same as PMF; it predates the DiscreteDist interface.
 func (s *Stairs) P(x int64) (p float64) {
-	if x < 0 || x > s.z {
+	return s.PMF(x)
+}
+
+// PMF returns the probability of index k.
+func (s *Stairs) PMF(k int64) float64 {
+	if k < 0 || k > s.z {
 		return 0.0
+	} else if k == 0 {
+		return s.p[0]
 	}
-	return s.p[x] - s.p[x-1]
+	return s.p[k] - s.p[k-1]
 }
 
-func (s *Stairs) Q(p float64) (x float64) {
-	if p < 0.0 {
+// CDF returns the probability of an index less than or equal to k.
+func (s *Stairs) CDF(k int64) float64 {
+	if k < 0 {
 		return 0.0
-	} else if p >= 1.0 {
-		return float64(s.z)
+	} else if k >= s.z {
+		return 1.0
 	}
+	return s.p[k]
+}
+
+// Quantile returns the smallest index k such that CDF(k) >= p.
+func (s *Stairs) Quantile(p float64) int64 {
 	for i, r := range s.p {
-		if r > p {
-			return float64(i)
+		if r >= p {
+			return int64(i)
 		}
 	}
-	return float64(s.z)
+	return s.z
+}
+
+// Q returns Quantile(p) as a float64, or NaN if p is not in [0, 1].
+func (s *Stairs) Q(p float64) (x float64) {
+	if !isProb(p) {
+		return math.NaN()
+	}
+	return float64(s.Quantile(p))
 }
 
 func (s *Stairs) Mean() float64 {
@@ -106,3 +131,33 @@ func (s *Stairs) Mean() float64 {
 	}
 	return mean
 }
+
+func (s *Stairs) Var() float64 {
+	return s.central(2)
+}
+
+func (s *Stairs) Std() float64 { return math.Sqrt(s.Var()) }
+
+func (s *Stairs) Skewness() float64 {
+	return s.central(3) / math.Pow(s.Var(), 1.5)
+}
+
+func (s *Stairs) ExcessKurtosis() float64 {
+	v := s.Var()
+	return s.central(4)/(v*v) - 3
+}
+
+// Moment returns the n-th raw moment.
+func (s *Stairs) Moment(n int) float64 {
+	return rawMoment(n, s.PMF, 0, s.z)
+}
+
+// central returns the n-th central moment.
+func (s *Stairs) central(n int) float64 {
+	m := s.Mean()
+	var c float64
+	for k := int64(0); k <= s.z; k++ {
+		c += math.Pow(float64(k)-m, float64(n)) * s.PMF(k)
+	}
+	return c
+}
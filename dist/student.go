@@ -0,0 +1,138 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/goulash/stat/statutil"
+)
+
+// StudentT is Student's t distribution with nu degrees of freedom.
+//
+// This is the distribution of Z/√(V/nu), where Z is standard normal and
+// V is chi-squared with nu degrees of freedom. It is the distribution of
+// the t statistic of a sample of size nu+1 from a normal distribution.
+type StudentT struct {
+	r  *rand.Rand
+	nu float64
+}
+
+// NewStudentT returns Student's t distribution with nu degrees of freedom.
+//
+// The random source s is only used for Float64, so if the distribution is
+// only used for P and Q, s may be nil.
+func NewStudentT(s rand.Source, nu float64) *StudentT {
+	if !(nu > 0) {
+		panic("degrees of freedom must be positive")
+	}
+
+	t := &StudentT{nu: nu}
+	if s != nil {
+		t.r = rand.New(s)
+	}
+	return t
+}
+
+func (t *StudentT) String() string {
+	return fmt.Sprintf("student-t [%v]", t.nu)
+}
+
+// DF returns the degrees of freedom.
+func (t *StudentT) DF() float64 { return t.nu }
+
+func (t *StudentT) Float64() float64 {
+	v := 2 * gammaFloat64(t.r, t.nu/2)
+	return t.r.NormFloat64() / math.Sqrt(v/t.nu)
+}
+
+func (t *StudentT) P(x float64) float64 { return statutil.StudentTCDF(x, t.nu) }
+func (t *StudentT) Q(p float64) float64 { return statutil.StudentTQuantile(p, t.nu) }
+
+// Survival returns the probability that a value is greater than x,
+// which is 1 - P(x) but retains its precision in the upper tail.
+// As the distribution is symmetric, this is P(-x), whose lower tail
+// StudentTCDF computes directly from the incomplete beta function.
+func (t *StudentT) Survival(x float64) float64 { return statutil.StudentTCDF(-x, t.nu) }
+
+// PDF returns the probability density at x.
+func (t *StudentT) PDF(x float64) float64 {
+	return math.Exp(t.LogPDF(x))
+}
+
+func (t *StudentT) LogPDF(x float64) float64 {
+	nu := t.nu
+	return statutil.LogGamma((nu+1)/2) - statutil.LogGamma(nu/2) -
+		0.5*math.Log(nu*math.Pi) - (nu+1)/2*math.Log1p(x*x/nu)
+}
+
+// Mean returns 0 for nu > 1, and NaN otherwise.
+func (t *StudentT) Mean() float64 {
+	if t.nu <= 1 {
+		return math.NaN()
+	}
+	return 0
+}
+
+// Var returns nu/(nu-2) for nu > 2, +Inf for 1 < nu ≤ 2, and NaN otherwise.
+func (t *StudentT) Var() float64 {
+	switch {
+	case t.nu > 2:
+		return t.nu / (t.nu - 2)
+	case t.nu > 1:
+		return math.Inf(1)
+	default:
+		return math.NaN()
+	}
+}
+
+func (t *StudentT) Std() float64 { return math.Sqrt(t.Var()) }
+
+// Skewness returns 0 for nu > 3, and NaN otherwise.
+func (t *StudentT) Skewness() float64 {
+	if t.nu <= 3 {
+		return math.NaN()
+	}
+	return 0
+}
+
+// ExcessKurtosis returns 6/(nu-4) for nu > 4, +Inf for 2 < nu ≤ 4,
+// and NaN otherwise.
+func (t *StudentT) ExcessKurtosis() float64 {
+	switch {
+	case t.nu > 4:
+		return 6 / (t.nu - 4)
+	case t.nu > 2:
+		return math.Inf(1)
+	default:
+		return math.NaN()
+	}
+}
+
+// Moment returns the n-th raw moment, which is finite for n < nu.
+// Odd moments are 0, and even moments are nu^(n/2) Π (2i-1)/(nu-2i)
+// for i from 1 to n/2. For n >= nu, even moments are +Inf, and odd
+// moments are undefined, so NaN is returned.
+func (t *StudentT) Moment(n int) float64 {
+	switch {
+	case n == 0:
+		return 1
+	case n < 0:
+		return math.NaN()
+	case float64(n) >= t.nu && n%2 == 0:
+		return math.Inf(1)
+	case float64(n) >= t.nu:
+		return math.NaN()
+	case n%2 == 1:
+		return 0
+	}
+	m := 1.0
+	for i := 1; i <= n/2; i++ {
+		m *= t.nu * float64(2*i-1) / (t.nu - float64(2*i))
+	}
+	return m
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package dist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestStudentT(z *testing.T) {
+	// With one degree of freedom, this is the Cauchy distribution.
+	c := NewStudentT(nil, 1)
+	for _, p := range []float64{0.01, 0.3, 0.5, 0.9, 0.975} {
+		want := math.Tan(math.Pi * (p - 0.5))
+		if x := c.Q(p); math.Abs(x-want) > 1e-9*math.Max(1, math.Abs(want)) {
+			z.Errorf("StudentT(1).Q(%v) = %v, want %v", p, x, want)
+		}
+	}
+	if !math.IsNaN(c.Mean()) || !math.IsNaN(c.Var()) || !math.IsNaN(c.Moment(1)) {
+		z.Errorf("StudentT(1) should have no mean and variance")
+	}
+
+	// Even moments diverge to +Inf like the variance, odd moments are
+	// undefined once they diverge.
+	for _, nu := range []float64{1.5, 2, 3, 9} {
+		d := NewStudentT(nil, nu)
+		if m2, v := d.Moment(2), d.Var(); math.Abs(m2-v) > 1e-12*v && !(math.IsInf(m2, 1) && math.IsInf(v, 1)) {
+			z.Errorf("StudentT(%v).Moment(2) = %v, want Var() = %v", nu, m2, v)
+		}
+	}
+	if m := c.Moment(2); !math.IsInf(m, 1) {
+		z.Errorf("StudentT(1).Moment(2) = %v, want +Inf", m)
+	}
+	if m := NewStudentT(nil, 3).Moment(3); !math.IsNaN(m) {
+		z.Errorf("StudentT(3).Moment(3) = %v, want NaN", m)
+	}
+
+	// With two degrees of freedom, the upper tail is
+	// 1/2 - x/(2√(x²+2)) = 1/(√(x²+2)(√(x²+2)+x)).
+	t2 := NewStudentT(nil, 2)
+	for _, x := range []float64{-3, 0, 0.5, 4, 1e3, 1e10} {
+		r := math.Sqrt(x*x + 2)
+		want := 1 / (r * (r + x))
+		if q := t2.Survival(x); math.Abs(q-want) > 1e-12*want {
+			z.Errorf("StudentT(2).Survival(%v) = %v, want %v", x, q, want)
+		}
+	}
+
+	t := NewStudentT(rand.NewSource(1), 9)
+	var m, s float64
+	const n = 200000
+	for i := 1; i <= n; i++ {
+		x := t.Float64()
+		d := x - m
+		m += d / float64(i)
+		s += d * (x - m)
+	}
+	if math.Abs(m) > 0.01 || math.Abs(s/(n-1)-t.Var()) > 0.02 {
+		z.Errorf("StudentT(9) sample mean %v and variance %v, want 0 and %v", m, s/(n-1), t.Var())
+	}
+}
@@ -36,6 +36,9 @@ func (u *UniformDiscrete) Int63() int64 {
 	return u.r.Int63n(u.b-u.a) + u.a
 }
 
+// P returns the probability that a value is less than x.
+//
+// Note: unlike CDF, this does not include x itself.
 func (u *UniformDiscrete) P(x int64) (p float64) {
 	if x < u.a {
 		return 0
@@ -45,15 +48,101 @@ func (u *UniformDiscrete) P(x int64) (p float64) {
 	return float64(x-u.a) / float64(u.b-u.a)
 }
 
+// Q returns Quantile(p) as a float64, or NaN if p is not in [0, 1].
 func (u *UniformDiscrete) Q(p float64) (x float64) {
-	if p < 0 {
-		return float64(u.a)
-	} else if p > 1 {
-		return float64(u.b)
+	if !isProb(p) {
+		return math.NaN()
 	}
-	return math.Floor(p*float64(u.b-u.a) + float64(u.a))
+	return float64(u.Quantile(p))
 }
 
+// PMF returns the probability of k.
+func (u *UniformDiscrete) PMF(k int64) float64 {
+	if k < u.a || k >= u.b {
+		return 0
+	}
+	return 1 / float64(u.b-u.a)
+}
+
+// CDF returns the probability of a value less than or equal to k.
+func (u *UniformDiscrete) CDF(k int64) float64 {
+	if k < u.a {
+		return 0
+	} else if k >= u.b-1 {
+		return 1
+	}
+	return float64(k-u.a+1) / float64(u.b-u.a)
+}
+
+// Quantile returns the smallest k such that CDF(k) >= p.
+func (u *UniformDiscrete) Quantile(p float64) int64 {
+	if p <= 0 {
+		return u.a
+	} else if p >= 1 {
+		return u.b - 1
+	}
+	return u.a + int64(math.Ceil(p*float64(u.b-u.a))) - 1
+}
+
+// Mean returns the mean, which is (a+b-1)/2, as b is not included.
 func (u *UniformDiscrete) Mean() float64 {
-	return float64(u.b-u.a)/2 + float64(u.a)
+	return float64(u.b-u.a-1)/2 + float64(u.a)
+}
+
+func (u *UniformDiscrete) Var() float64 {
+	n := float64(u.b - u.a)
+	return (n*n - 1) / 12
+}
+
+func (u *UniformDiscrete) Std() float64 { return math.Sqrt(u.Var()) }
+
+func (u *UniformDiscrete) Skewness() float64 {
+	if u.b-u.a == 1 {
+		return math.NaN()
+	}
+	return 0
+}
+
+// ExcessKurtosis returns -6(n²+1)/(5(n²-1)), where n = b-a. If there is
+// only one value, the variance is zero and NaN is returned, as for Skewness.
+func (u *UniformDiscrete) ExcessKurtosis() float64 {
+	if u.b-u.a == 1 {
+		return math.NaN()
+	}
+	n2 := float64(u.b-u.a) * float64(u.b-u.a)
+	return -6 * (n2 + 1) / (5 * (n2 - 1))
+}
+
+// Moment returns the n-th raw moment, which is computed with Faulhaber's
+// formula, so that it does not depend on the size of the support.
+func (u *UniformDiscrete) Moment(n int) float64 {
+	if n < 0 {
+		return math.NaN()
+	}
+
+	// Bernoulli numbers with B1 = -1/2, so that S(j) below is the sum
+	// of k^j for k in [0, N).
+	bs := make([]float64, n+1)
+	for m := range bs {
+		bs[m] = indicator(m == 0)
+		for k := 0; k < m; k++ {
+			bs[m] -= choose(m+1, k) * bs[k] / float64(m+1)
+		}
+	}
+	N := float64(u.b - u.a)
+	S := func(j int) float64 {
+		var s float64
+		for i := 0; i <= j; i++ {
+			s += choose(j+1, i) * bs[i] * math.Pow(N, float64(j+1-i))
+		}
+		return s / float64(j+1)
+	}
+
+	// Expand (a+k)^n binomially and sum over k.
+	var m float64
+	a := float64(u.a)
+	for j := 0; j <= n; j++ {
+		m += choose(n, j) * math.Pow(a, float64(n-j)) * S(j)
+	}
+	return m / N
 }
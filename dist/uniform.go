@@ -6,6 +6,7 @@ package dist
 
 import (
 	"fmt"
+	"math"
 	"math/rand"
 )
 
@@ -44,14 +45,42 @@ func (u *Uniform) P(x float64) (p float64) {
 }
 
 func (u *Uniform) Q(p float64) (x float64) {
-	if p < 0 {
-		return u.a
-	} else if p > 1 {
-		return u.b
+	if !isProb(p) {
+		return math.NaN()
 	}
 	return p*(u.b-u.a) + u.a
 }
 
+// PDF returns the probability density at x, which is 1/(b-a) within [a, b].
+func (u *Uniform) PDF(x float64) float64 {
+	if x < u.a || x > u.b {
+		return 0
+	}
+	return 1 / (u.b - u.a)
+}
+
+func (u *Uniform) LogPDF(x float64) float64 {
+	return math.Log(u.PDF(x))
+}
+
 func (u *Uniform) Mean() float64 {
 	return u.Q(0.5)
 }
+
+func (u *Uniform) Var() float64 {
+	d := u.b - u.a
+	return d * d / 12
+}
+
+func (u *Uniform) Std() float64            { return math.Sqrt(u.Var()) }
+func (u *Uniform) Skewness() float64       { return 0 }
+func (u *Uniform) ExcessKurtosis() float64 { return -6.0 / 5.0 }
+
+// Moment returns the n-th raw moment, (b^(n+1) - a^(n+1)) / ((n+1)(b-a)).
+func (u *Uniform) Moment(n int) float64 {
+	if n < 0 {
+		return math.NaN()
+	}
+	f := float64(n + 1)
+	return (math.Pow(u.b, f) - math.Pow(u.a, f)) / (f * (u.b - u.a))
+}
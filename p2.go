@@ -0,0 +1,143 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"math"
+	"sort"
+)
+
+// P2 estimates a single fixed quantile of a stream of values in O(1) memory.
+//
+// It implements the P² algorithm by Jain and Chlamtac, "The P² Algorithm
+// for Dynamic Calculation of Quantiles and Histograms Without Storing
+// Observations" (1985). Five markers are kept, whose heights are adjusted
+// with piecewise-parabolic interpolation as values arrive.
+//
+// The algorithm gives no hard error bound, but for smooth distributions
+// the estimate converges quickly to the true quantile; after a few
+// thousand values the error is usually well below one percent of the
+// spread of the data. Extreme quantiles of heavy-tailed data converge
+// more slowly. If you need to merge estimates or query arbitrary
+// quantiles, use Sketch instead.
+//
+// A P2 must be created with NewP2, as the zero value has no quantile to
+// estimate; Add panics if it is used.
+type P2 struct {
+	p  float64
+	n  int64
+	q  [5]float64 // marker heights
+	k  [5]float64 // marker positions
+	kd [5]float64 // desired marker positions
+	dk [5]float64 // increments of the desired marker positions
+}
+
+// NewP2 returns a new P2 that estimates the p-quantile, where p is in [0, 1].
+func NewP2(p float64) *P2 {
+	if !(0 <= p && p <= 1) {
+		panic("p must be in [0, 1]")
+	}
+	e := &P2{p: p}
+	e.Reset()
+	return e
+}
+
+// Reset removes all values, but keeps the quantile that is estimated.
+func (e *P2) Reset() {
+	p := e.p
+	*e = P2{
+		p:  p,
+		kd: [5]float64{0, 2 * p, 4 * p, 2 + 2*p, 4},
+		dk: [5]float64{0, p / 2, p, (1 + p) / 2, 1},
+	}
+	e.k = [5]float64{0, 1, 2, 3, 4}
+}
+
+// P returns the quantile that is estimated.
+func (e *P2) P() float64 { return e.p }
+
+// N returns the number of values that have been added.
+func (e *P2) N() int64 { return e.n }
+
+// Add a new value to the estimate.
+func (e *P2) Add(x float64) {
+	if e.dk[4] == 0 {
+		panic("P2 must be created with NewP2")
+	} else if e.n < 5 {
+		e.q[e.n] = x
+		e.n++
+		if e.n == 5 {
+			sort.Float64s(e.q[:])
+		}
+		return
+	}
+	e.n++
+
+	// Find the cell k such that q[k] <= x < q[k+1], adjusting the extremes.
+	var k int
+	switch {
+	case x < e.q[0]:
+		e.q[0] = x
+		k = 0
+	case x >= e.q[4]:
+		e.q[4] = x
+		k = 3
+	default:
+		for k = 0; k < 3; k++ {
+			if x < e.q[k+1] {
+				break
+			}
+		}
+	}
+
+	for i := k + 1; i < 5; i++ {
+		e.k[i]++
+	}
+	for i := range e.kd {
+		e.kd[i] += e.dk[i]
+	}
+
+	// Adjust the heights of the three middle markers if necessary.
+	for i := 1; i <= 3; i++ {
+		d := e.kd[i] - e.k[i]
+		if (d >= 1 && e.k[i+1]-e.k[i] > 1) || (d <= -1 && e.k[i-1]-e.k[i] < -1) {
+			d = math.Copysign(1, d)
+			q := e.parabolic(i, d)
+			if !(e.q[i-1] < q && q < e.q[i+1]) {
+				q = e.linear(i, d)
+			}
+			e.q[i] = q
+			e.k[i] += d
+		}
+	}
+}
+
+func (e *P2) parabolic(i int, d float64) float64 {
+	q, k := &e.q, &e.k
+	return q[i] + d/(k[i+1]-k[i-1])*
+		((k[i]-k[i-1]+d)*(q[i+1]-q[i])/(k[i+1]-k[i])+
+			(k[i+1]-k[i]-d)*(q[i]-q[i-1])/(k[i]-k[i-1]))
+}
+
+func (e *P2) linear(i int, d float64) float64 {
+	j := i + int(d)
+	return e.q[i] + d*(e.q[j]-e.q[i])/(e.k[j]-e.k[i])
+}
+
+// Quantile returns the current estimate of the quantile.
+//
+// As long as fewer than five values have been added, the exact
+// quantile of these values is returned. If no values have been
+// added, NaN is returned.
+func (e *P2) Quantile() float64 {
+	if e.n == 0 {
+		return math.NaN()
+	} else if e.n < 5 {
+		s := make(Series, e.n)
+		copy(s, e.q[:e.n])
+		return Quantile(s, e.p)
+	}
+	return e.q[2]
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestP2(z *testing.T) {
+	assert := assert.New(z)
+
+	r := rand.New(rand.NewSource(1))
+	ps := []float64{0.5, 0.9, 0.95, 0.99}
+	es := make([]*P2, len(ps))
+	for i, p := range ps {
+		es[i] = NewP2(p)
+	}
+
+	s := make(Series, 20000)
+	for i := range s {
+		s[i] = r.NormFloat64()
+		for _, e := range es {
+			e.Add(s[i])
+		}
+	}
+
+	for i, p := range ps {
+		assert.Equal(int64(len(s)), es[i].N(), "n")
+		assert.InDelta(Quantile(s, p), es[i].Quantile(), 0.02, "p=%v", p)
+	}
+
+	e := NewP2(0.5)
+	assert.True(math.IsNaN(e.Quantile()), "empty estimate")
+	e.Add(3)
+	e.Add(1)
+	e.Add(2)
+	assert.Equal(2.0, e.Quantile(), "few values give the exact quantile")
+	e.Reset()
+	assert.Equal(int64(0), e.N(), "reset")
+	assert.Equal(0.5, e.P(), "reset keeps p")
+
+	assert.Panics(func() { new(P2).Add(1) }, "zero value is not usable")
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"math"
+	"sort"
+)
+
+// QuantileMethod selects one of the nine sample quantile definitions
+// described by Hyndman and Fan in "Sample Quantiles in Statistical
+// Packages" (1996). The numbering is the same as that of the type
+// parameter of the quantile function in R.
+//
+// Methods HF1 to HF3 are discontinuous and always return a value from
+// the series; methods HF4 to HF9 interpolate linearly between the two
+// closest values. The default method is HF7, which is also the default
+// of R, NumPy, and spreadsheets.
+type QuantileMethod int
+
+const (
+	HF1 QuantileMethod = iota + 1 // inverse of the empirical distribution function
+	HF2                           // like HF1, but averaging at discontinuities
+	HF3                           // nearest even order statistic
+	HF4                           // linear interpolation of the empirical distribution function
+	HF5                           // piecewise linear with knots at the midpoints
+	HF6                           // p[k] = E[F(x[k])], used by Minitab and SPSS
+	HF7                           // p[k] = mode[F(x[k])], the default
+	HF8                           // approximately median-unbiased, recommended by Hyndman and Fan
+	HF9                           // approximately unbiased for normally distributed data
+)
+
+// Quantile returns the p-quantile of the series s according to method m.
+//
+// If s is empty or p is not in [0, 1], NaN is returned.
+//
+// Calculating a quantile requires sorting a copy of the series.
+func (m QuantileMethod) Quantile(s Series, p float64) float64 {
+	if len(s) == 0 {
+		return math.NaN()
+	}
+	return m.SortedQuantile(sorted(s), p)
+}
+
+// Quantiles returns the quantiles of the series s for each p in ps
+// according to method m. The series is only sorted once.
+func (m QuantileMethod) Quantiles(s Series, ps ...float64) Series {
+	t := sorted(s)
+	qs := make(Series, len(ps))
+	for i, p := range ps {
+		qs[i] = m.SortedQuantile(t, p)
+	}
+	return qs
+}
+
+// SortedQuantile returns the p-quantile of the series s according to
+// method m, where s must already be sorted in increasing order.
+//
+// If s is empty or p is not in [0, 1], NaN is returned.
+// If s is not sorted, the result is undefined.
+func (m QuantileMethod) SortedQuantile(s Series, p float64) float64 {
+	n := len(s)
+	if n == 0 || !(0 <= p && p <= 1) {
+		return math.NaN()
+	}
+
+	// x returns the i-th order statistic, where i starts at 1,
+	// and values outside the series are clamped to the extremes.
+	x := func(i float64) float64 {
+		if i < 1 {
+			return s[0]
+		} else if int(i) > n {
+			return s[n-1]
+		}
+		return s[int(i)-1]
+	}
+
+	f := float64(n)
+	var h float64
+	switch m {
+	case HF1:
+		return x(math.Ceil(f * p))
+	case HF2:
+		h = f*p + 0.5
+		return (x(math.Ceil(h-0.5)) + x(math.Floor(h+0.5))) / 2
+	case HF3:
+		return x(math.RoundToEven(f * p))
+	case HF4:
+		h = f * p
+	case HF5:
+		h = f*p + 0.5
+	case HF6:
+		h = (f + 1) * p
+	case HF7:
+		h = (f-1)*p + 1
+	case HF8:
+		h = (f+1.0/3.0)*p + 1.0/3.0
+	case HF9:
+		h = (f+0.25)*p + 3.0/8.0
+	default:
+		panic("unknown quantile method")
+	}
+
+	k := math.Floor(h)
+	lo := x(k)
+	return lo + (h-k)*(x(k+1)-lo)
+}
+
+// Quantile returns the p-quantile of the series s, using the default
+// method HF7. See QuantileMethod for other methods.
+//
+// If s is empty or p is not in [0, 1], NaN is returned.
+//
+// Calculating a quantile requires sorting a copy of the series.
+func Quantile(s Series, p float64) float64 {
+	return HF7.Quantile(s, p)
+}
+
+// Quantiles returns the quantiles of the series s for each p in ps,
+// using the default method HF7. The series is only sorted once.
+func Quantiles(s Series, ps ...float64) Series {
+	return HF7.Quantiles(s, ps...)
+}
+
+// SortedQuantile returns the p-quantile of the series s, which must
+// already be sorted in increasing order. The default method HF7 is used.
+//
+// This avoids the copy and sort that Quantile requires.
+func SortedQuantile(s Series, p float64) float64 {
+	return HF7.SortedQuantile(s, p)
+}
+
+// SortedMedian returns the median of the series s, which must already
+// be sorted in increasing order.
+//
+// If s is empty, NaN is returned.
+func SortedMedian(s Series) float64 {
+	return HF7.SortedQuantile(s, 0.5)
+}
+
+// Percentile returns the q-th percentile of the series s, where q is in
+// [0, 100]. This is the same as Quantile(s, q/100).
+func Percentile(s Series, q float64) float64 {
+	return Quantile(s, q/100)
+}
+
+// IQR returns the interquartile range of the series s, which is the
+// difference between the third and the first quartile.
+//
+// If s is empty, NaN is returned.
+func IQR(s Series) float64 {
+	qs := Quantiles(s, 0.25, 0.75)
+	return qs[1] - qs[0]
+}
+
+// FiveNumberSummary returns the minimum, first quartile, median,
+// third quartile, and maximum of the series s.
+//
+// If s is empty, all values are NaN.
+func FiveNumberSummary(s Series) (min, q1, med, q3, max float64) {
+	if len(s) == 0 {
+		nan := math.NaN()
+		return nan, nan, nan, nan, nan
+	}
+
+	t := sorted(s)
+	return t[0], SortedQuantile(t, 0.25), SortedMedian(t), SortedQuantile(t, 0.75), t[len(t)-1]
+}
+
+// sorted returns a sorted copy of s.
+func sorted(s Series) Series {
+	t := s.Copy()
+	sort.Float64s(t)
+	return t
+}
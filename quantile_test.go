@@ -0,0 +1,60 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuantileMethods(z *testing.T) {
+	assert := assert.New(z)
+
+	// Reference values from R: quantile(1:10, c(0.1, 0.5), type=m)
+	s := Series{10, 9, 8, 7, 6, 5, 4, 3, 2, 1}
+	tests := []struct {
+		M      QuantileMethod
+		Q1, Q2 float64
+	}{
+		{HF1, 1, 5},
+		{HF2, 1.5, 5.5},
+		{HF3, 1, 5},
+		{HF4, 1, 5},
+		{HF5, 1.5, 5.5},
+		{HF6, 1.1, 5.5},
+		{HF7, 1.9, 5.5},
+		{HF8, 1.3666666666666667, 5.5},
+		{HF9, 1.4, 5.5},
+	}
+	for _, t := range tests {
+		qs := t.M.Quantiles(s, 0.1, 0.5)
+		assert.InDelta(t.Q1, qs[0], 1e-12, "HF%d: 0.1-quantile", t.M)
+		assert.InDelta(t.Q2, qs[1], 1e-12, "HF%d: median", t.M)
+		assert.Equal(1.0, t.M.Quantile(s, 0), "HF%d: 0-quantile is the minimum", t.M)
+		assert.Equal(10.0, t.M.Quantile(s, 1), "HF%d: 1-quantile is the maximum", t.M)
+		assert.True(math.IsNaN(t.M.Quantile(s, 1.5)), "HF%d: p out of range", t.M)
+		assert.True(math.IsNaN(t.M.Quantile(Series{}, 0.5)), "HF%d: empty series", t.M)
+	}
+}
+
+func TestQuantileHelpers(z *testing.T) {
+	assert := assert.New(z)
+
+	s := Series{4, 1, 3, 2, 5}
+	assert.Equal(3.0, s.Quantile(0.5), "median")
+	assert.Equal(2.0, s.Percentile(25), "25th percentile")
+	assert.Equal(2.0, s.IQR(), "interquartile range")
+	assert.Equal(Series{1, 3, 5}, s.Quantiles(0, 0.5, 1), "quantiles")
+	assert.Equal(Series{4, 1, 3, 2, 5}, s, "the series should not be sorted in place")
+
+	min, q1, med, q3, max := FiveNumberSummary(s)
+	assert.Equal([]float64{1, 2, 3, 4, 5}, []float64{min, q1, med, q3, max}, "five number summary")
+
+	assert.Equal(7.0, Series{7}.Median(), "median of a single element")
+	assert.Equal(2.5, SortedMedian(Series{1, 2, 3, 4}), "sorted median")
+	assert.True(math.IsNaN(IQR(Series{})), "empty series")
+}
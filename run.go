@@ -6,14 +6,23 @@ package stat
 
 import "math"
 
-// Run calculates the running mean, variance, and standard deviation.
+// Run calculates the running mean, variance, standard deviation,
+// skewness, and kurtosis.
+//
+// Values may be added with a weight, see AddWeighted. Values added
+// with Add have a weight of one, in which case all the statistics
+// are the same as those of the corresponding Series functions.
 //
 // Note: Run contains only plain old datatypes, so a shallow copy is
 // a complete copy.
 type Run struct {
-	n int64
-	m float64
-	s float64
+	n  int64
+	w  float64 // sum of weights
+	w2 float64 // sum of squared weights
+	m  float64
+	s  float64
+	s3 float64 // sum of cubed deviations from the mean
+	s4 float64 // sum of fourth powers of deviations from the mean
 
 	max float64
 	min float64
@@ -22,8 +31,12 @@ type Run struct {
 // Reset the values to zero.
 func (r *Run) Reset() {
 	r.n = 0
+	r.w = 0
+	r.w2 = 0
 	r.m = 0
 	r.s = 0
+	r.s3 = 0
+	r.s4 = 0
 	r.max = math.Inf(-1)
 	r.min = math.Inf(1)
 }
@@ -34,50 +47,139 @@ func (r *Run) Add(x float64) {
 	// Run to be used. All we need is r.min = Inf and r.max = -Inf.
 	// If only we could have a constructor.
 	if r.n == 0 {
-		r.n = 1
-		r.m = x
-		r.min = x
-		r.max = x
+		*r = Run{n: 1, w: 1, w2: 1, m: x, min: x, max: x}
 		return
 	}
 
 	r.n++
+	r.w++
+	r.w2++
 	if r.max < x {
 		r.max = x
 	}
 	if r.min > x {
 		r.min = x
 	}
-	m := r.m + (x-r.m)/float64(r.n)
+	// The higher moments are updated with the formulas given by Pébay,
+	// which must happen before r.s is updated, as they depend on it.
+	n := r.w
+	d := x - r.m
+	dn := d / n
+	t := d * dn * (n - 1)
+	r.s4 += t*dn*dn*(n*n-3*n+3) + 6*dn*dn*r.s - 4*dn*r.s3
+	r.s3 += t*dn*(n-2) - 3*dn*r.s
+
+	m := r.m + (x-r.m)/n
 	r.s = r.s + (x-r.m)*(x-m)
 	r.m = m
 }
 
+// AddN adds the value x to the run n times.
+//
+// This is the same as calling Add(x) n times, only faster.
+// If n is not positive, nothing is added.
 func (r *Run) AddN(n int64, x float64) {
+	if n <= 0 {
+		return
+	}
+	f := float64(n)
+	r.Merge(Run{n: n, w: f, w2: f, m: x, min: x, max: x})
+}
+
+// AddWeighted adds the value x with weight w to the run.
+//
+// Weights can be interpreted in two ways, and Run supports both:
+//
+// Frequency weights count how often a value occurred, so that adding
+// x with weight 3 is the same as adding x three times. The methods Var
+// and Std assume this interpretation.
+//
+// Reliability weights express the relative importance of a value,
+// and are typically normalized so that they sum to one. The methods
+// VarR and StdR assume this interpretation; they are unbiased regardless
+// of how the weights are scaled.
+//
+// In both cases, N counts the number of calls to AddWeighted, while
+// Weight returns the sum of the weights. Values with a weight of zero
+// are ignored. A negative or NaN weight causes a panic.
+func (r *Run) AddWeighted(x, w float64) {
+	if !(w >= 0) {
+		panic("weight must be non-negative")
+	}
+	if w == 0 {
+		return
+	}
+	r.Merge(Run{n: 1, w: w, w2: w * w, m: x, min: x, max: x})
+}
+
+// Merge adds all the values that were added to o to the run r.
+//
+// The result is the same as if the values had all been added to r,
+// up to floating point rounding. This makes it possible to accumulate
+// values in several runs concurrently and combine them afterwards.
+// The pairwise update formulas are those of Chan et al., extended to
+// the third and fourth moments by Pébay.
+func (r *Run) Merge(o Run) {
+	if o.n == 0 {
+		return
+	}
 	if r.n == 0 {
-		r.n = n
-		r.m = x
-		r.max = x
-		r.min = x
+		*r = o
 		return
 	}
 
-	if r.max < x {
-		r.max = x
+	na, nb := r.w, o.w
+	n := na + nb
+	d := o.m - r.m
+	d2 := d * d
+
+	r.s4 += o.s4 + d2*d2*na*nb*(na*na-na*nb+nb*nb)/(n*n*n) +
+		6*d2*(na*na*o.s+nb*nb*r.s)/(n*n) + 4*d*(na*o.s3-nb*r.s3)/n
+	r.s3 += o.s3 + d2*d*na*nb*(na-nb)/(n*n) + 3*d*(na*o.s-nb*r.s)/n
+	r.s += o.s + d2*na*nb/n
+	r.m += d * nb / n
+	r.n += o.n
+	r.w += o.w
+	r.w2 += o.w2
+
+	if r.max < o.max {
+		r.max = o.max
 	}
-	if r.min > x {
-		r.min = x
+	if r.min > o.min {
+		r.min = o.min
 	}
-	// TODO: Double check this calculation!!!
-	i := float64(n - r.n)
-	m := r.m + (i*x-i*r.m)/float64(n)
-	r.s = r.s + i*(x-r.m)*(x-m)
-	r.m = m
-	r.n = n
 }
 
+// Combine returns a run that contains the values of all the given runs.
+//
+// See Run.Merge for more information.
+func Combine(rs ...Run) Run {
+	var r Run
+	for _, o := range rs {
+		r.Merge(o)
+	}
+	return r
+}
+
+// N returns the number of values that have been added.
 func (r *Run) N() int64 { return r.n }
 
+// Weight returns the sum of the weights of the values that have been added.
+//
+// If all values were added with Add or AddN, this is the same as N.
+func (r *Run) Weight() float64 { return r.w }
+
+// EffectiveN returns Kish's effective sample size, (Σw)²/Σw².
+//
+// If all values have the same weight, this is the same as N.
+// If no values have been added, NaN is returned.
+func (r *Run) EffectiveN() float64 {
+	if r.n == 0 {
+		return math.NaN()
+	}
+	return r.w * r.w / r.w2
+}
+
 // Max returns the max.
 func (r *Run) Max() float64 {
 	if r.n == 0 {
@@ -103,14 +205,32 @@ func (r *Run) Mean() float64 {
 }
 
 // Var returns the sample variance.
+//
+// If weights are used, they are treated as frequency weights. If the
+// total weight of two or more values is at most 1, NaN is returned.
 func (r *Run) Var() float64 {
+	if r.n <= 1 {
+		if r.n == 0 {
+			return math.NaN()
+		}
+		return 0
+	} else if r.w <= 1 {
+		return math.NaN()
+	}
+	return r.s / (r.w - 1)
+}
+
+// VarR returns the sample variance, treating weights as reliability weights.
+//
+// If all values were added with Add, this is the same as Var.
+func (r *Run) VarR() float64 {
 	if r.n <= 1 {
 		if r.n == 0 {
 			return math.NaN()
 		}
 		return 0
 	}
-	return r.s / float64(r.n-1)
+	return r.s / (r.w - r.w2/r.w)
 }
 
 // VarP returns the population variance.
@@ -121,7 +241,7 @@ func (r *Run) VarP() float64 {
 		}
 		return 0
 	}
-	return r.s / float64(r.n)
+	return r.s / r.w
 }
 
 // Std returns the sample standard deviation.
@@ -129,7 +249,82 @@ func (r *Run) Std() float64 {
 	return math.Sqrt(r.Var())
 }
 
+// StdR returns the sample standard deviation, treating weights as
+// reliability weights.
+func (r *Run) StdR() float64 {
+	return math.Sqrt(r.VarR())
+}
+
 // StdP returns the population standard deviation.
 func (r *Run) StdP() float64 {
 	return math.Sqrt(r.VarP())
 }
+
+// Skew returns the sample skewness.
+//
+// If fewer than three values have been added, or their total weight is
+// at most 2, NaN is returned. See the Skew function for the definition used.
+func (r *Run) Skew() float64 {
+	if r.n < 3 || r.w <= 2 {
+		return math.NaN()
+	}
+	n := r.w
+	return r.SkewP() * math.Sqrt(n*(n-1)) / (n - 2)
+}
+
+// SkewP returns the population skewness.
+//
+// If no values have been added, NaN is returned.
+func (r *Run) SkewP() float64 {
+	if r.n == 0 {
+		return math.NaN()
+	}
+	return math.Sqrt(r.w) * r.s3 / math.Pow(r.s, 1.5)
+}
+
+// Kurtosis returns the sample excess kurtosis.
+//
+// If fewer than four values have been added, or their total weight is
+// at most 3, NaN is returned. See the Kurtosis function for the definition used.
+func (r *Run) Kurtosis() float64 {
+	if r.n < 4 || r.w <= 3 {
+		return math.NaN()
+	}
+	n := r.w
+	return ((n+1)*r.KurtosisP() + 6) * (n - 1) / ((n - 2) * (n - 3))
+}
+
+// KurtosisP returns the population excess kurtosis.
+//
+// If no values have been added, NaN is returned.
+func (r *Run) KurtosisP() float64 {
+	if r.n == 0 {
+		return math.NaN()
+	}
+	return r.w*r.s4/(r.s*r.s) - 3
+}
+
+// MeanCI returns the lower and upper bounds of the confidence interval
+// of the mean, at the given confidence level, such as 0.95.
+//
+// If weights are used, they are treated as frequency weights, as in Var,
+// so the number of values is the total weight. See the MeanCI function
+// for more information.
+func (r *Run) MeanCI(level float64) (lo, hi float64) {
+	return meanCI(r.w, r.Mean(), r.Std(), level, false)
+}
+
+// MeanCINormal returns the confidence interval of the mean based on
+// the normal approximation, with weights treated as in MeanCI.
+// See the MeanCINormal function.
+func (r *Run) MeanCINormal(level float64) (lo, hi float64) {
+	return meanCI(r.w, r.Mean(), r.Std(), level, true)
+}
+
+// MoreSamples returns an estimate of how much more weight needs to be
+// added so that the half-width of the confidence interval of the mean
+// is at most precision·|mean|. Without weights, this is the number of
+// values. See the MoreSamples function.
+func (r *Run) MoreSamples(level, precision float64) int64 {
+	return moreSamples(r.w, r.Mean(), r.Std(), level, precision)
+}
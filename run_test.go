@@ -19,6 +19,12 @@ func TestRunSeries(z *testing.T) {
 		}
 		assert.Equal(a, b)
 	}
+	var assertNear = func(a, b float64) {
+		if math.IsNaN(a) && math.IsNaN(b) {
+			return // ok
+		}
+		assert.InDelta(a, b, 1e-9)
+	}
 
 	tests := []Series{
 		{1, 2, 3, 4, 5},
@@ -42,5 +48,138 @@ func TestRunSeries(z *testing.T) {
 		assertFloat(t.Std(), r.Std())
 		assertFloat(t.VarP(), r.VarP())
 		assertFloat(t.StdP(), r.StdP())
+		assertNear(t.Skew(), r.Skew())
+		assertNear(t.SkewP(), r.SkewP())
+		assertNear(t.Kurtosis(), r.Kurtosis())
+		assertNear(t.KurtosisP(), r.KurtosisP())
 	}
 }
+
+func TestRunMerge(z *testing.T) {
+	assert := assert.New(z)
+
+	s := Series{
+		0.38809179, 0.94113008, 0.15350705, 0.03311646, 0.68168087, 0.21719990,
+		0.32123922, 0.57085251, 0.53576882, 0.38965630, 0.27487263, 0.90783122,
+		-4, 12, 7, 7, 7,
+	}
+	var all Run
+	for _, x := range s {
+		all.Add(x)
+	}
+
+	// Split the series at every possible point and merge both halves.
+	for i := 0; i <= len(s); i++ {
+		var a, b Run
+		for _, x := range s[:i] {
+			a.Add(x)
+		}
+		for _, x := range s[i:] {
+			b.Add(x)
+		}
+		a.Merge(b)
+
+		assert.Equal(all.N(), a.N(), "split %d: n", i)
+		assert.Equal(all.Min(), a.Min(), "split %d: min", i)
+		assert.Equal(all.Max(), a.Max(), "split %d: max", i)
+		assert.InDelta(all.Mean(), a.Mean(), 1e-12, "split %d: mean", i)
+		assert.InDelta(all.Var(), a.Var(), 1e-12, "split %d: var", i)
+		assert.InDelta(all.Skew(), a.Skew(), 1e-12, "split %d: skew", i)
+		assert.InDelta(all.Kurtosis(), a.Kurtosis(), 1e-12, "split %d: kurtosis", i)
+	}
+
+	// Combine runs of one value each.
+	rs := make([]Run, len(s))
+	for i, x := range s {
+		rs[i].Add(x)
+	}
+	c := Combine(rs...)
+	assert.Equal(all.N(), c.N(), "combine: n")
+	assert.InDelta(all.Mean(), c.Mean(), 1e-12, "combine: mean")
+	assert.InDelta(all.Var(), c.Var(), 1e-12, "combine: var")
+	assert.InDelta(all.Skew(), c.Skew(), 1e-12, "combine: skew")
+	assert.InDelta(all.Kurtosis(), c.Kurtosis(), 1e-12, "combine: kurtosis")
+
+	empty := Combine()
+	assert.Equal(int64(0), empty.N(), "combining nothing gives nothing")
+	empty.Merge(Run{})
+	assert.True(math.IsNaN(empty.Mean()), "merging empty runs stays empty")
+}
+
+func TestRunAddN(z *testing.T) {
+	assert := assert.New(z)
+
+	type obs struct {
+		N int64
+		X float64
+	}
+	tests := [][]obs{
+		{{1, 3}},
+		{{5, 3}},
+		{{3, 1}, {2, 5}, {1, -2}},
+		{{1, 0.25}, {10, 0.5}, {4, 0.125}, {0, 100}},
+	}
+
+	for _, t := range tests {
+		var a, b, c Run
+		for _, o := range t {
+			a.AddN(o.N, o.X)
+			c.AddWeighted(o.X, float64(o.N))
+			for i := int64(0); i < o.N; i++ {
+				b.Add(o.X)
+			}
+		}
+
+		assert.Equal(b.N(), a.N(), "%v: n", t)
+		assert.Equal(b.Weight(), c.Weight(), "%v: weight", t)
+		assert.Equal(b.Min(), a.Min(), "%v: min", t)
+		assert.Equal(b.Max(), a.Max(), "%v: max", t)
+		for _, r := range []Run{a, c} {
+			assert.InDelta(b.Mean(), r.Mean(), 1e-12, "%v: mean", t)
+			assert.InDelta(b.Var(), r.Var(), 1e-12, "%v: var", t)
+			assert.InDelta(b.VarP(), r.VarP(), 1e-12, "%v: varp", t)
+		}
+		assert.InDelta(b.EffectiveN(), a.EffectiveN(), 1e-12, "%v: effective n", t)
+		if len(t) > 1 {
+			assert.InDelta(b.Skew(), a.Skew(), 1e-9, "%v: skew", t)
+			assert.InDelta(b.Kurtosis(), a.Kurtosis(), 1e-9, "%v: kurtosis", t)
+		}
+	}
+}
+
+func TestRunWeighted(z *testing.T) {
+	assert := assert.New(z)
+
+	var r Run
+	xs := []float64{1, 2, 3, 4}
+	ws := []float64{0.1, 0.2, 0.3, 0.4}
+	for i, x := range xs {
+		r.AddWeighted(x, ws[i])
+	}
+	r.AddWeighted(100, 0)
+
+	assert.Equal(int64(4), r.N(), "zero weights are ignored")
+	assert.InDelta(1.0, r.Weight(), 1e-12, "sum of weights")
+	assert.InDelta(3.0, r.Mean(), 1e-12, "weighted mean")
+	assert.InDelta(1.0, r.VarP(), 1e-12, "weighted population variance")
+	assert.InDelta(1.4285714285714286, r.VarR(), 1e-12, "reliability weighted variance")
+	assert.InDelta(3.3333333333333333, r.EffectiveN(), 1e-12, "effective sample size")
+	assert.Equal(4.0, r.Max(), "max")
+
+	// Scaling reliability weights does not change the estimate.
+	var q Run
+	for i, x := range xs {
+		q.AddWeighted(x, 10*ws[i])
+	}
+	assert.InDelta(r.VarR(), q.VarR(), 1e-12, "reliability weights are scale invariant")
+
+	// As frequency weights, normalized weights are too few for the
+	// sample statistics.
+	assert.True(math.IsNaN(r.Var()), "var with total weight 1")
+	assert.True(math.IsNaN(r.Std()), "std with total weight 1")
+	assert.True(math.IsNaN(r.Skew()), "skew with total weight 1")
+	assert.True(math.IsNaN(r.Kurtosis()), "kurtosis with total weight 1")
+	assert.False(math.IsNaN(q.Kurtosis()), "kurtosis with total weight 10")
+
+	assert.Panics(func() { r.AddWeighted(1, -1) }, "negative weights are invalid")
+}
@@ -4,71 +4,77 @@
 
 package stat
 
-import "math"
-
 // Running calculates the running means, variances, and standard deviation over time.
+//
+// After every Add, a snapshot of the Run is stored, so that the state of
+// all statistics can be retrieved as it was after the i-th value. This is
+// useful for plotting the convergence of simulation estimates.
+//
+// Running keeps the entire history and reflects all values ever added.
+// To follow only the recent behaviour, see MovingWindow, TimeWindow, and EWMA.
 type Running struct {
 	z Run
 
 	ts []Time
-	ms Series
-	ss Series
+	rs []Run
 }
 
 // I don't want to take along the baggage of a time.Time.
 type Time uint64
 
+// Add a new value x at time t.
 func (r *Running) Add(t Time, x float64) {
 	r.z.Add(x)
 	r.ts = append(r.ts, t)
-	r.ms.Append(r.z.m)
-	r.ss.Append(r.z.s)
+	r.rs = append(r.rs, r.z)
 }
 
+// Len returns the number of values that have been added.
+func (r *Running) Len() int { return len(r.rs) }
+
+// Run returns the current state of the run, which is the same as the
+// last snapshot. If nothing has been added, an empty Run is returned.
+func (r *Running) Run() Run { return r.z }
+
+// Snapshot returns the run as it was after the i-th value was added,
+// where i starts at zero.
+//
+// If i is out of range, the function panics.
+func (r *Running) Snapshot(i int) Run { return r.rs[i] }
+
 func (r *Running) Times() []Time {
 	ts := make([]Time, len(r.ts))
 	copy(ts, r.ts)
 	return ts
 }
 
-func (r *Running) Means() Series {
-	ms := make(Series, len(r.ms))
-	copy(ms, r.ms)
-	return ms
-}
-
-func (r *Running) Vars() Series {
-	ss := make(Series, len(r.ss))
-	n := float64(r.z.n - 1)
-	for i, m := range r.ss {
-		ss[i] = m / n
+// series returns f applied to each snapshot.
+func (r *Running) series(f func(*Run) float64) Series {
+	s := make(Series, len(r.rs))
+	for i := range r.rs {
+		s[i] = f(&r.rs[i])
 	}
-	return ss
+	return s
 }
 
-func (r *Running) VarsP() Series {
-	ss := make(Series, len(r.ss))
-	n := float64(r.z.n)
-	for i, m := range r.ss {
-		ss[i] = m / n
-	}
-	return ss
-}
-
-func (r *Running) Stds() Series {
-	ss := make(Series, len(r.ss))
-	n := float64(r.z.n - 1)
-	for i, m := range r.ss {
-		ss[i] = math.Sqrt(m / n)
-	}
-	return ss
-}
+func (r *Running) Ns() Series    { return r.series(func(z *Run) float64 { return float64(z.N()) }) }
+func (r *Running) Mins() Series  { return r.series((*Run).Min) }
+func (r *Running) Maxs() Series  { return r.series((*Run).Max) }
+func (r *Running) Means() Series { return r.series((*Run).Mean) }
+func (r *Running) Vars() Series  { return r.series((*Run).Var) }
+func (r *Running) VarsP() Series { return r.series((*Run).VarP) }
+func (r *Running) Stds() Series  { return r.series((*Run).Std) }
+func (r *Running) StdsP() Series { return r.series((*Run).StdP) }
 
-func (r *Running) StdsP() Series {
-	ss := make(Series, len(r.ss))
-	n := float64(r.z.n)
-	for i, m := range r.ss {
-		ss[i] = math.Sqrt(m / n)
+// MeanCIs returns the lower and upper bounds of the confidence interval
+// of the mean at each step, for the given confidence level, such as 0.95.
+//
+// See Run.MeanCI for how the interval is calculated. At the first step,
+// the bounds are NaN, as the variance cannot be estimated.
+func (r *Running) MeanCIs(level float64) (lo, hi Series) {
+	lo, hi = make(Series, len(r.rs)), make(Series, len(r.rs))
+	for i := range r.rs {
+		lo[i], hi[i] = r.rs[i].MeanCI(level)
 	}
-	return ss
+	return lo, hi
 }
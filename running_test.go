@@ -0,0 +1,47 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunning(z *testing.T) {
+	assert := assert.New(z)
+
+	s := Series{
+		0.38809179, 0.94113008, 0.15350705, 0.03311646, 0.68168087, 0.21719990,
+		0.32123922, 0.57085251, 0.53576882, 0.38965630, 0.27487263, 0.90783122,
+	}
+	var r Running
+	for i, x := range s {
+		r.Add(Time(10*i), x)
+	}
+
+	assert.Equal(len(s), r.Len(), "len")
+	assert.Equal(Time(110), r.Times()[11], "times")
+	vars, stds, mins, maxs := r.Vars(), r.StdsP(), r.Mins(), r.Maxs()
+	lo, hi := r.MeanCIs(0.95)
+	for i := 1; i < len(s); i++ {
+		t := s[:i+1]
+		assert.InDelta(t.Var(), vars[i], 1e-12, "var at step %d", i)
+		assert.InDelta(t.StdP(), stds[i], 1e-12, "stdp at step %d", i)
+		assert.Equal(t.Min(), mins[i], "min at step %d", i)
+		assert.Equal(t.Max(), maxs[i], "max at step %d", i)
+		assert.InDelta(t.Mean(), (lo[i]+hi[i])/2, 1e-12, "interval is centered at step %d", i)
+		assert.True(lo[i] < hi[i], "interval is not empty at step %d", i)
+
+		snap := r.Snapshot(i)
+		assert.Equal(int64(i+1), snap.N(), "snapshot n at step %d", i)
+		assert.InDelta(t.Mean(), snap.Mean(), 1e-12, "snapshot mean at step %d", i)
+	}
+	assert.True(math.IsNaN(lo[0]), "no interval at the first step")
+
+	last := r.Run()
+	assert.Equal(r.Snapshot(len(s)-1), last, "current run is the last snapshot")
+}
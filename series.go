@@ -10,7 +10,6 @@ import (
 	"bytes"
 	"math"
 	"os"
-	"sort"
 	"strconv"
 )
 
@@ -82,12 +81,19 @@ func (s Series) Max() float64                       { return Max(s) }
 func (s Series) Min() float64                       { return Min(s) }
 func (s Series) Mean() float64                      { return Mean(s) }
 func (s Series) Median() float64                    { return Median(s) }
+func (s Series) Quantile(p float64) float64         { return Quantile(s, p) }
+func (s Series) Quantiles(ps ...float64) Series     { return Quantiles(s, ps...) }
+func (s Series) Percentile(q float64) float64       { return Percentile(s, q) }
+func (s Series) IQR() float64                       { return IQR(s) }
 func (s Series) Var() float64                       { return Var(s) }
 func (s Series) VarP() float64                      { return VarP(s) }
 func (s Series) Std() float64                       { return Std(s) }
 func (s Series) StdP() float64                      { return StdP(s) }
 func (s Series) Skew() float64                      { return Skew(s) }
 func (s Series) SkewP() float64                     { return SkewP(s) }
+func (s Series) Kurtosis() float64                  { return Kurtosis(s) }
+func (s Series) KurtosisP() float64                 { return KurtosisP(s) }
+func (s Series) MeanCI(l float64) (lo, hi float64)  { return MeanCI(s, l) }
 func (s Series) Autocov(lag int) float64            { return Autocov(s, lag) }
 func (s Series) Autocor(lag int) float64            { return Autocor(s, lag) }
 func (s Series) Cov(t Series) float64               { return Cov(s, t) }
@@ -173,21 +179,15 @@ func Mean(s Series) float64 {
 // If s has an even number of elements, the mean of the two middle
 // elements is returned.
 //
-// If s is empty or has only one element, NaN is returned.
+// If s is empty, NaN is returned.
 //
 // Calculating the median requires sorting a copy of the series.
+// If the series is already sorted, use SortedMedian instead.
 func Median(s Series) float64 {
-	if len(s) <= 1 {
+	if len(s) == 0 {
 		return math.NaN()
 	}
-
-	n := len(s)
-	t := s.Copy()
-	sort.Float64s(t)
-	if n%2 == 0 {
-		return (t[n/2] + t[n/2-1]) / 2.0
-	}
-	return t[n/2]
+	return SortedMedian(sorted(s))
 }
 
 // Var returns the sample variance of the series.
@@ -239,18 +239,81 @@ func StdP(s Series) float64 {
 	return math.Sqrt(VarP(s))
 }
 
-// Skew returns the sample skew of the series.
+// Skew returns the sample skewness of the series.
+//
+// This is the adjusted Fisher-Pearson coefficient of skewness G1,
+// which corrects the population skewness for the bias of small samples.
 //
-// NOTE: Not implemented yet.
+// If s has fewer than three elements, NaN is returned.
 func Skew(s Series) float64 {
-	panic("not implemented")
+	n := float64(len(s))
+	if n < 3 {
+		return math.NaN()
+	}
+	return SkewP(s) * math.Sqrt(n*(n-1)) / (n - 2)
 }
 
-// SkewP returns the population skew of the series.
+// SkewP returns the population skewness of the series.
 //
-// NOTE: Not implemented yet.
+// This is the Fisher-Pearson coefficient of skewness g1 = m3 / m2^(3/2),
+// where mk is the k-th central moment of s.
+//
+// If s is empty, NaN is returned.
 func SkewP(s Series) float64 {
-	panic("not implemented")
+	n, m2, m3, _ := moments(s)
+	if n == 0 {
+		return math.NaN()
+	}
+	return math.Sqrt(n) * m3 / math.Pow(m2, 1.5)
+}
+
+// Kurtosis returns the sample excess kurtosis of the series.
+//
+// This is the bias-corrected estimator G2, which is what most
+// statistical software reports as the sample kurtosis.
+//
+// If s has fewer than four elements, NaN is returned.
+func Kurtosis(s Series) float64 {
+	n := float64(len(s))
+	if n < 4 {
+		return math.NaN()
+	}
+	return ((n+1)*KurtosisP(s) + 6) * (n - 1) / ((n - 2) * (n - 3))
+}
+
+// KurtosisP returns the population excess kurtosis of the series.
+//
+// This is g2 = m4 / m2^2 - 3, where mk is the k-th central moment of s,
+// so that a normal distribution has an excess kurtosis of zero.
+//
+// If s is empty, NaN is returned.
+func KurtosisP(s Series) float64 {
+	n, m2, _, m4 := moments(s)
+	if n == 0 {
+		return math.NaN()
+	}
+	return n*m4/(m2*m2) - 3
+}
+
+// moments returns the length of xs and the sums of the second, third,
+// and fourth powers of the deviations from the mean.
+//
+// The mean is computed first, so that the deviations do not suffer
+// from the cancellation that the naive power-sum formulas have.
+func moments(xs Series) (n, m2, m3, m4 float64) {
+	if len(xs) == 0 {
+		return 0, 0, 0, 0
+	}
+
+	m := Mean(xs)
+	for _, x := range xs {
+		d := x - m
+		d2 := d * d
+		m2 += d2
+		m3 += d2 * d
+		m4 += d2 * d2
+	}
+	return float64(len(xs)), m2, m3, m4
 }
 
 // Cov returns the sample covariance of two series s and t.
@@ -20,6 +20,8 @@ type test struct {
 	Mean, Median     float64
 	Var, VarP        float64
 	Std, StdP        float64
+	Skew, SkewP      float64
+	Kurt, KurtP      float64
 	Autocov, Autocor []float64 // start with lag 0
 	Cov, CovP        float64
 	Cor              float64
@@ -59,6 +61,14 @@ func TestMedian(z *testing.T) {
 	assert.Equal((3.0+4.0)/2.0, t.Median(), "median should be equal")
 }
 
+func TestSkewKurtosisShort(z *testing.T) {
+	assert := assert.New(z)
+	assert.True(math.IsNaN(Series{}.SkewP()), "empty series has no skew")
+	assert.True(math.IsNaN(Series{1, 2}.Skew()), "sample skew needs three values")
+	assert.True(math.IsNaN(Series{1, 2, 3}.Kurtosis()), "sample kurtosis needs four values")
+	assert.Equal(0.0, Series{1, 2, 3}.Skew(), "symmetric series has no skew")
+}
+
 func round(num float64) int {
 	return int(num + math.Copysign(0.5, num))
 }
@@ -91,6 +101,10 @@ func TestSeries(z *testing.T) {
 		assert(t.VarP, a.VarP(), "varp should be equal")
 		assert(t.Std, a.Std(), "std should be equal")
 		assert(t.StdP, a.StdP(), "stdp shoulb be equal")
+		assert(t.Skew, a.Skew(), "skew should be equal")
+		assert(t.SkewP, a.SkewP(), "skewp should be equal")
+		assert(t.Kurt, a.Kurtosis(), "kurtosis should be equal")
+		assert(t.KurtP, a.KurtosisP(), "kurtosisp should be equal")
 		for i, x := range t.Autocov {
 			assert(x, a.Autocov(i), fmt.Sprintf("autocov with lag %d should be equal", i))
 		}
@@ -122,6 +136,10 @@ var tests = []test{
 		VarP:   0.0747546644979665,
 		Std:    0.285570557111007,
 		StdP:   0.273412992555157,
+		Skew:   0.500360079414734,
+		SkewP:  0.435507548791618,
+		Kurt:   -0.554178307239849,
+		KurtP:  -0.810322011549555,
 		Autocov: []float64{
 			0.081550543, -0.024985019, -0.032008039, 0.036685824, -0.002588976, -0.024764554,
 			-0.013166106, 0.059028468, -0.050665201, -0.113928076, 0.175025168, NaN, NaN,
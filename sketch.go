@@ -0,0 +1,257 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"math"
+	"sort"
+)
+
+// DefaultSketchK is the accuracy parameter used by a zero-valued Sketch.
+const DefaultSketchK = 200
+
+// Sketch estimates arbitrary quantiles of a stream of values in
+// O(k log(n/k)) memory, and can be merged with other sketches.
+//
+// It implements the KLL sketch by Karnin, Lang, and Liberty, "Optimal
+// Quantile Approximation in Streams" (2016). Values are kept in a
+// hierarchy of compactors; when a compactor is full, it is sorted and
+// every other value is promoted to the next level with twice the weight.
+//
+// The error is measured in rank: the value returned for the p-quantile
+// has a true rank within p ± ε of the data, where ε is roughly 1.7/k
+// with high probability. For the default k of 200 this is less than
+// one percent. Merging sketches does not increase this bound.
+//
+// The zero value of Sketch is ready to use, with k = DefaultSketchK.
+type Sketch struct {
+	k      int
+	n      int64
+	size   int
+	levels [][]float64
+	coin   uint64
+
+	min float64
+	max float64
+}
+
+// NewSketch returns a new Sketch with accuracy parameter k.
+// Larger values of k are more accurate but require more memory.
+func NewSketch(k int) *Sketch {
+	if k < 8 {
+		panic("k must be at least 8")
+	}
+	return &Sketch{k: k}
+}
+
+// Reset removes all values, but keeps the accuracy parameter.
+func (s *Sketch) Reset() {
+	*s = Sketch{k: s.k}
+}
+
+// N returns the number of values that have been added.
+func (s *Sketch) N() int64 { return s.n }
+
+// K returns the accuracy parameter of the sketch.
+func (s *Sketch) K() int {
+	if s.k == 0 {
+		return DefaultSketchK
+	}
+	return s.k
+}
+
+// Min returns the exact minimum, or +∞ if the sketch is empty.
+func (s *Sketch) Min() float64 {
+	if s.n == 0 {
+		return math.Inf(1)
+	}
+	return s.min
+}
+
+// Max returns the exact maximum, or -∞ if the sketch is empty.
+func (s *Sketch) Max() float64 {
+	if s.n == 0 {
+		return math.Inf(-1)
+	}
+	return s.max
+}
+
+// Add a new value to the sketch.
+func (s *Sketch) Add(x float64) {
+	if s.n == 0 {
+		s.min, s.max = x, x
+		s.levels = [][]float64{nil}
+	} else if x < s.min {
+		s.min = x
+	} else if x > s.max {
+		s.max = x
+	}
+
+	s.n++
+	s.size++
+	s.levels[0] = append(s.levels[0], x)
+	s.compress()
+}
+
+// Merge adds all the values of o to the sketch s. The sketch o is
+// not modified, and the accuracy parameter of s is retained.
+func (s *Sketch) Merge(o *Sketch) {
+	if o.n == 0 {
+		return
+	}
+	if s.n == 0 {
+		s.min, s.max = o.min, o.max
+	} else {
+		s.min = math.Min(s.min, o.min)
+		s.max = math.Max(s.max, o.max)
+	}
+
+	for len(s.levels) < len(o.levels) {
+		s.levels = append(s.levels, nil)
+	}
+	for h, xs := range o.levels {
+		s.levels[h] = append(s.levels[h], xs...)
+	}
+	s.n += o.n
+	s.size += o.size
+	s.compress()
+}
+
+// capacity returns the number of values that level h may hold.
+// Lower levels have exponentially smaller capacities than the top.
+func (s *Sketch) capacity(h int) int {
+	depth := len(s.levels) - h - 1
+	c := int(math.Ceil(float64(s.K()) * math.Pow(2.0/3.0, float64(depth))))
+	if c < 2 {
+		return 2
+	}
+	return c
+}
+
+func (s *Sketch) maxSize() int {
+	var m int
+	for h := range s.levels {
+		m += s.capacity(h)
+	}
+	return m
+}
+
+// compress compacts full levels until the sketch is within its capacity.
+func (s *Sketch) compress() {
+	for s.size >= s.maxSize() {
+		for h := range s.levels {
+			if len(s.levels[h]) >= s.capacity(h) {
+				s.compact(h)
+				break
+			}
+		}
+	}
+}
+
+// compact promotes every other value of level h to level h+1.
+func (s *Sketch) compact(h int) {
+	if h+1 == len(s.levels) {
+		s.levels = append(s.levels, nil)
+	}
+
+	xs := s.levels[h]
+	sort.Float64s(xs)
+	var rest []float64
+	if len(xs)%2 == 1 {
+		xs, rest = xs[:len(xs)-1], xs[len(xs)-1:]
+	}
+	for i := int(s.flip()); i < len(xs); i += 2 {
+		s.levels[h+1] = append(s.levels[h+1], xs[i])
+	}
+	s.levels[h] = append(s.levels[h][:0], rest...)
+	s.size -= len(xs) / 2
+}
+
+// flip returns a pseudo-random bit, using the SplitMix64 generator.
+// This keeps the zero value usable and the sketch deterministic.
+func (s *Sketch) flip() uint64 {
+	s.coin += 0x9E3779B97F4A7C15
+	z := s.coin
+	z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+	z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+	return (z ^ (z >> 31)) & 1
+}
+
+type weighted struct {
+	x float64
+	w int64
+}
+
+// sorted returns the retained values with their weights in increasing order.
+func (s *Sketch) sorted() []weighted {
+	ws := make([]weighted, 0, s.size)
+	for h, xs := range s.levels {
+		for _, x := range xs {
+			ws = append(ws, weighted{x, 1 << uint(h)})
+		}
+	}
+	sort.Slice(ws, func(i, j int) bool { return ws[i].x < ws[j].x })
+	return ws
+}
+
+// Quantile returns an estimate of the p-quantile of the values added.
+//
+// The 0-quantile and the 1-quantile are the exact minimum and maximum.
+// If the sketch is empty or p is not in [0, 1], NaN is returned.
+func (s *Sketch) Quantile(p float64) float64 {
+	return s.Quantiles(p)[0]
+}
+
+// Quantiles returns estimates of the quantiles for each p in ps.
+func (s *Sketch) Quantiles(ps ...float64) Series {
+	qs := make(Series, len(ps))
+	if s.n == 0 {
+		for i := range qs {
+			qs[i] = math.NaN()
+		}
+		return qs
+	}
+
+	ws := s.sorted()
+	for i, p := range ps {
+		switch {
+		case !(0 <= p && p <= 1):
+			qs[i] = math.NaN()
+		case p == 0:
+			qs[i] = s.min
+		case p == 1:
+			qs[i] = s.max
+		default:
+			target := p * float64(s.n)
+			var cum int64
+			for _, w := range ws {
+				cum += w.w
+				if float64(cum) >= target {
+					qs[i] = w.x
+					break
+				}
+			}
+		}
+	}
+	return qs
+}
+
+// CDF returns an estimate of the fraction of values that are less
+// than or equal to x. If the sketch is empty, NaN is returned.
+func (s *Sketch) CDF(x float64) float64 {
+	if s.n == 0 {
+		return math.NaN()
+	}
+
+	var cum int64
+	for h, xs := range s.levels {
+		for _, y := range xs {
+			if y <= x {
+				cum += 1 << uint(h)
+			}
+		}
+	}
+	return float64(cum) / float64(s.n)
+}
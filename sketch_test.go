@@ -0,0 +1,61 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rank returns the fraction of values in the sorted series s that are <= x.
+func rank(s Series, x float64) float64 {
+	return float64(sort.Search(len(s), func(i int) bool { return s[i] > x })) / float64(len(s))
+}
+
+func TestSketch(z *testing.T) {
+	assert := assert.New(z)
+
+	r := rand.New(rand.NewSource(1))
+	ps := []float64{0.01, 0.1, 0.25, 0.5, 0.75, 0.9, 0.95, 0.99}
+
+	// Exponentially distributed values, split across four shards.
+	var all Sketch
+	shards := make([]Sketch, 4)
+	s := make(Series, 100000)
+	for i := range s {
+		s[i] = r.ExpFloat64()
+		all.Add(s[i])
+		shards[i%len(shards)].Add(s[i])
+	}
+	var merged Sketch
+	for i := range shards {
+		merged.Merge(&shards[i])
+	}
+	sort.Float64s(s)
+
+	for _, k := range []*Sketch{&all, &merged} {
+		assert.Equal(int64(len(s)), k.N(), "n")
+		assert.Equal(s[0], k.Min(), "min")
+		assert.Equal(s[len(s)-1], k.Max(), "max")
+		qs := k.Quantiles(ps...)
+		for i, p := range ps {
+			assert.InDelta(p, rank(s, qs[i]), 0.01, "rank of p=%v", p)
+		}
+		assert.InDelta(0.5, k.CDF(SortedMedian(s)), 0.01, "cdf of the median")
+	}
+
+	var e Sketch
+	assert.True(math.IsNaN(e.Quantile(0.5)), "empty sketch")
+	e.Add(1)
+	assert.Equal(1.0, e.Quantile(0.5), "single value")
+	assert.True(math.IsNaN(e.Quantile(-1)), "p out of range")
+	all.Reset()
+	assert.Equal(int64(0), all.N(), "reset")
+	assert.Equal(DefaultSketchK, all.K(), "reset keeps k")
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package statutil
+
+import "math"
+
+// IncBeta returns the regularized incomplete beta function I_x(a, b),
+// where a, b > 0 and x is in [0, 1].
+//
+// For invalid arguments, NaN is returned.
+func IncBeta(a, b, x float64) float64 {
+	switch {
+	case !(a > 0 && b > 0) || !(0 <= x && x <= 1):
+		return math.NaN()
+	case x == 0:
+		return 0
+	case x == 1:
+		return 1
+	}
+
+	// The prefactor x^a (1-x)^b / B(a, b), computed in logs.
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	lab, _ := math.Lgamma(a + b)
+	f := math.Exp(lab - la - lb + a*math.Log(x) + b*math.Log1p(-x))
+
+	// The continued fraction converges quickly for x < (a+1)/(a+b+2);
+	// otherwise we use the symmetry I_x(a, b) = 1 - I_{1-x}(b, a).
+	if x < (a+1)/(a+b+2) {
+		return f * betacf(a, b, x) / a
+	}
+	return 1 - f*betacf(b, a, 1-x)/b
+}
+
+// betacf evaluates the continued fraction for the incomplete beta function
+// with the modified Lentz method, see Numerical Recipes §6.4.
+func betacf(a, b, x float64) float64 {
+	const (
+		maxIter = 1000
+		eps     = 1e-16
+		tiny    = 1e-300
+	)
+
+	qab, qap, qam := a+b, a+1, a-1
+	c := 1.0
+	d := 1 - qab*x/qap
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	h := d
+	for m := 1; m <= maxIter; m++ {
+		fm := float64(m)
+		m2 := 2 * fm
+
+		// Even step of the recurrence.
+		aa := fm * (b - fm) * x / ((qam + m2) * (a + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		h *= d * c
+
+		// Odd step of the recurrence.
+		aa = -(a + fm) * (qab + fm) * x / ((a + m2) * (qap + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+		if math.Abs(del-1) < eps {
+			break
+		}
+	}
+	return h
+}
+
+// LogBeta returns the natural logarithm of the beta function B(a, b),
+// where a, b > 0.
+func LogBeta(a, b float64) float64 {
+	return LogGamma(a) + LogGamma(b) - LogGamma(a+b)
+}
+
+// IncBetaInv returns x such that IncBeta(a, b, x) = p, where a, b > 0 and
+// p is in [0, 1].
+//
+// For invalid arguments, NaN is returned.
+func IncBetaInv(a, b, p float64) float64 {
+	switch {
+	case !(a > 0 && b > 0) || !(0 <= p && p <= 1):
+		return math.NaN()
+	case p == 0:
+		return 0
+	case p == 1:
+		return 1
+	}
+
+	// Initial guess and Halley iteration from Numerical Recipes §6.4.1.
+	const eps = 1e-12
+	var x float64
+	a1, b1 := a-1, b-1
+	if a >= 1 && b >= 1 {
+		y := -normalGuess(p)
+		al := (y*y - 3) / 6
+		h := 2 / (1/(2*a-1) + 1/(2*b-1))
+		w := (y*math.Sqrt(al+h)/h - (1/(2*b-1)-1/(2*a-1))*(al+5.0/6.0-2/(3*h)))
+		x = a / (a + b*math.Exp(2*w))
+	} else {
+		lna, lnb := math.Log(a/(a+b)), math.Log(b/(a+b))
+		t := math.Exp(a*lna) / a
+		u := math.Exp(b*lnb) / b
+		w := t + u
+		if p < t/w {
+			x = math.Pow(a*w*p, 1/a)
+		} else {
+			x = 1 - math.Pow(b*w*(1-p), 1/b)
+		}
+	}
+
+	afac := -LogBeta(a, b)
+	for i := 0; i < 100; i++ {
+		if x == 0 || x == 1 {
+			return x
+		}
+		err := IncBeta(a, b, x) - p
+		t := math.Exp(a1*math.Log(x) + b1*math.Log1p(-x) + afac)
+		u := err / t
+		t = u / (1 - 0.5*math.Min(1, u*(a1/x-b1/(1-x))))
+		x -= t
+		if x <= 0 {
+			x = 0.5 * (x + t)
+		}
+		if x >= 1 {
+			x = 0.5 * (x + t + 1)
+		}
+		if math.Abs(t) < eps*x && i > 0 {
+			break
+		}
+	}
+	return x
+}
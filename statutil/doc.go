@@ -0,0 +1,13 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+// Package statutil provides special functions and helpers that the
+// distributions and tests in the other packages are built on.
+//
+// The regularized incomplete gamma and beta functions and their inverses
+// make it possible to compute the exact cumulative distribution functions
+// and quantiles of the chi-squared, Student's t, F, gamma, beta, binomial,
+// and related distributions. The functions Lgamma, Erf, Erfinv and Erfcinv
+// of the math package complement those provided here.
+package statutil
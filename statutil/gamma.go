@@ -0,0 +1,216 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package statutil
+
+import "math"
+
+// IncGammaP returns the regularized lower incomplete gamma function P(a, x),
+// where a > 0 and x >= 0.
+//
+// For invalid arguments, NaN is returned.
+func IncGammaP(a, x float64) float64 {
+	switch {
+	case !(a > 0) || !(x >= 0):
+		return math.NaN()
+	case x == 0:
+		return 0
+	case math.IsInf(x, 1):
+		return 1
+	case x < a+1:
+		return gammaSeries(a, x)
+	default:
+		return 1 - gammaFraction(a, x)
+	}
+}
+
+// IncGammaQ returns the regularized upper incomplete gamma function
+// Q(a, x) = 1 - P(a, x), where a > 0 and x >= 0.
+//
+// For invalid arguments, NaN is returned.
+func IncGammaQ(a, x float64) float64 {
+	switch {
+	case !(a > 0) || !(x >= 0):
+		return math.NaN()
+	case x == 0:
+		return 1
+	case math.IsInf(x, 1):
+		return 0
+	case x < a+1:
+		return 1 - gammaSeries(a, x)
+	default:
+		return gammaFraction(a, x)
+	}
+}
+
+// gammaPrefix returns x^a e^-x / Γ(a), computed in logs.
+func gammaPrefix(a, x float64) float64 {
+	lg, _ := math.Lgamma(a)
+	return math.Exp(a*math.Log(x) - x - lg)
+}
+
+// gammaSeries evaluates P(a, x) by its series representation,
+// which converges quickly for x < a+1; see Numerical Recipes §6.2.
+func gammaSeries(a, x float64) float64 {
+	const (
+		maxIter = 1000
+		eps     = 1e-16
+	)
+
+	ap := a
+	del := 1 / a
+	sum := del
+	for i := 0; i < maxIter; i++ {
+		ap++
+		del *= x / ap
+		sum += del
+		if math.Abs(del) < math.Abs(sum)*eps {
+			break
+		}
+	}
+	return sum * gammaPrefix(a, x)
+}
+
+// gammaFraction evaluates Q(a, x) by its continued fraction representation
+// with the modified Lentz method, which converges quickly for x >= a+1.
+func gammaFraction(a, x float64) float64 {
+	const (
+		maxIter = 1000
+		eps     = 1e-16
+		tiny    = 1e-300
+	)
+
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for i := 1; i <= maxIter; i++ {
+		an := -float64(i) * (float64(i) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+		if math.Abs(del-1) < eps {
+			break
+		}
+	}
+	return h * gammaPrefix(a, x)
+}
+
+// LogGamma returns the natural logarithm of |Γ(x)|.
+//
+// This is math.Lgamma without the sign, which is positive for all x > 0.
+func LogGamma(x float64) float64 {
+	lg, _ := math.Lgamma(x)
+	return lg
+}
+
+// Digamma returns the digamma function ψ(x), the logarithmic derivative
+// of the gamma function.
+//
+// At zero and the negative integers, where ψ has poles, NaN is returned.
+func Digamma(x float64) float64 {
+	switch {
+	case math.IsNaN(x) || math.IsInf(x, -1):
+		return math.NaN()
+	case math.IsInf(x, 1):
+		return x
+	case x <= 0 && x == math.Floor(x):
+		return math.NaN()
+	case x < 0:
+		// Reflection formula: ψ(1-x) - ψ(x) = π cot(πx).
+		return Digamma(1-x) - math.Pi/math.Tan(math.Pi*x)
+	}
+
+	// Use the recurrence ψ(x+1) = ψ(x) + 1/x to shift x far enough
+	// for the asymptotic expansion to be accurate.
+	var r float64
+	for x < 10 {
+		r -= 1 / x
+		x++
+	}
+	f := 1 / (x * x)
+	return r + math.Log(x) - 0.5/x -
+		f*(1.0/12-f*(1.0/120-f*(1.0/252-f*(1.0/240-f*(1.0/132-f*691.0/32760)))))
+}
+
+// IncGammaPInv returns x such that IncGammaP(a, x) = p, where a > 0 and
+// p is in [0, 1].
+//
+// For invalid arguments, NaN is returned.
+func IncGammaPInv(a, p float64) float64 {
+	switch {
+	case !(a > 0) || !(0 <= p && p <= 1):
+		return math.NaN()
+	case p == 0:
+		return 0
+	case p == 1:
+		return math.Inf(1)
+	}
+
+	// Initial guess and Halley iteration from Numerical Recipes §6.2.1.
+	const eps = 1e-12
+	var x, t, lna1, afac float64
+	a1 := a - 1
+	gln := LogGamma(a)
+	if a > 1 {
+		lna1 = math.Log(a1)
+		afac = math.Exp(a1*(lna1-1) - gln)
+		z := normalGuess(p)
+		x = math.Max(1e-3, a*math.Pow(1-1/(9*a)+z/(3*math.Sqrt(a)), 3))
+	} else {
+		t = 1 - a*(0.253+a*0.12)
+		if p < t {
+			x = math.Pow(p/t, 1/a)
+		} else {
+			x = 1 - math.Log(1-(p-t)/(1-t))
+		}
+	}
+
+	for i := 0; i < 100; i++ {
+		if x <= 0 {
+			return 0
+		}
+		err := IncGammaP(a, x) - p
+		if a > 1 {
+			t = afac * math.Exp(-(x-a1)+a1*(math.Log(x)-lna1))
+		} else {
+			t = math.Exp(-x + a1*math.Log(x) - gln)
+		}
+		u := err / t
+		t = u / (1 - 0.5*math.Min(1, u*((a-1)/x-1)))
+		x -= t
+		if x <= 0 {
+			x = 0.5 * (x + t)
+		}
+		if math.Abs(t) < eps*x {
+			break
+		}
+	}
+	return x
+}
+
+// normalGuess returns a rough approximation of the p-quantile of the
+// standard normal distribution, accurate to about 3e-3, as a starting
+// point for iterative methods.
+func normalGuess(p float64) float64 {
+	pp := p
+	if p >= 0.5 {
+		pp = 1 - p
+	}
+	t := math.Sqrt(-2 * math.Log(pp))
+	x := (2.30753+t*0.27061)/(1+t*(0.99229+t*0.04481)) - t
+	if p < 0.5 {
+		return x
+	}
+	return -x
+}
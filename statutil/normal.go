@@ -0,0 +1,20 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package statutil
+
+import "math"
+
+// NormalQuantile returns the p-quantile of the standard normal distribution.
+//
+// If p is 0 or 1, -∞ or +∞ is returned; if p is not in [0, 1], NaN is returned.
+func NormalQuantile(p float64) float64 {
+	return math.Sqrt2 * math.Erfinv(2*p-1)
+}
+
+// NormalCDF returns the cumulative probability of the standard normal
+// distribution at x.
+func NormalCDF(x float64) float64 {
+	return 0.5 * math.Erfc(-x/math.Sqrt2)
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package statutil
+
+import (
+	"math"
+	"testing"
+)
+
+func near(a, b, eps float64) bool {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return math.IsNaN(a) && math.IsNaN(b)
+	}
+	if math.IsInf(a, 0) || math.IsInf(b, 0) {
+		return a == b
+	}
+	return math.Abs(a-b) <= eps*math.Max(1, math.Abs(b))
+}
+
+func TestIncBeta(z *testing.T) {
+	tests := []struct {
+		A, B, X, Want float64
+	}{
+		{1, 1, 0.3, 0.3},
+		{2, 3, 0.4, 0.5248},
+		{0.5, 0.5, 0.5, 0.5},
+		{5, 0.5, 0.9, 0.3166429150200},
+		{10, 10, 0.5, 0.5},
+		{2, 3, 0, 0},
+		{2, 3, 1, 1},
+		{2, 3, 1.5, math.NaN()},
+		{-1, 3, 0.5, math.NaN()},
+	}
+	for _, t := range tests {
+		if got := IncBeta(t.A, t.B, t.X); !near(got, t.Want, 1e-11) {
+			z.Errorf("IncBeta(%v, %v, %v) = %v, want %v", t.A, t.B, t.X, got, t.Want)
+		}
+	}
+}
+
+func TestStudentT(z *testing.T) {
+	tests := []struct {
+		P, Nu, Want float64
+	}{
+		{0.975, 1, 12.706204736174698},
+		{0.975, 10, 2.2281388519862744},
+		{0.995, 5, 4.032142983557536},
+		{0.95, 30, 1.6972608865939574},
+		{0.025, 10, -2.2281388519862744},
+		{0.5, 3, 0},
+		{0.975, math.Inf(1), 1.959963984540054},
+		{1, 3, math.Inf(1)},
+		{0, 3, math.Inf(-1)},
+		{2, 3, math.NaN()},
+	}
+	for _, t := range tests {
+		got := StudentTQuantile(t.P, t.Nu)
+		if !near(got, t.Want, 1e-10) {
+			z.Errorf("StudentTQuantile(%v, %v) = %v, want %v", t.P, t.Nu, got, t.Want)
+		}
+		if math.IsInf(got, 0) || math.IsNaN(got) {
+			continue
+		}
+		if p := StudentTCDF(got, t.Nu); !near(p, t.P, 1e-12) {
+			z.Errorf("StudentTCDF(%v, %v) = %v, want %v", got, t.Nu, p, t.P)
+		}
+	}
+}
+
+func TestIncGamma(z *testing.T) {
+	tests := []struct {
+		A, X, Want float64
+	}{
+		{1, 0.5, 1 - math.Exp(-0.5)},
+		{1, 30, 1 - math.Exp(-30)},
+		{0.5, 2, math.Erf(math.Sqrt(2))},
+		{3, 2, 1 - 5*math.Exp(-2)},
+		{3, 20, 1 - 221*math.Exp(-20)},
+		{2, 0, 0},
+		{2, math.Inf(1), 1},
+		{0, 1, math.NaN()},
+		{1, -1, math.NaN()},
+	}
+	for _, t := range tests {
+		if got := IncGammaP(t.A, t.X); !near(got, t.Want, 1e-13) {
+			z.Errorf("IncGammaP(%v, %v) = %v, want %v", t.A, t.X, got, t.Want)
+		}
+		if got := IncGammaQ(t.A, t.X); !near(got, 1-t.Want, 1e-13) {
+			z.Errorf("IncGammaQ(%v, %v) = %v, want %v", t.A, t.X, got, 1-t.Want)
+		}
+	}
+}
+
+func TestDigamma(z *testing.T) {
+	const euler = 0.5772156649015329
+	tests := []struct {
+		X, Want float64
+	}{
+		{1, -euler},
+		{0.5, -euler - 2*math.Ln2},
+		{2, 1 - euler},
+		{10, 2.251752589066721},
+		{-0.5, 0.03648997397857652},
+		{0, math.NaN()},
+		{-2, math.NaN()},
+	}
+	for _, t := range tests {
+		if got := Digamma(t.X); !near(got, t.Want, 1e-13) {
+			z.Errorf("Digamma(%v) = %v, want %v", t.X, got, t.Want)
+		}
+	}
+}
+
+func TestLogBeta(z *testing.T) {
+	if got, want := LogBeta(2, 3), math.Log(1.0/12); !near(got, want, 1e-14) {
+		z.Errorf("LogBeta(2, 3) = %v, want %v", got, want)
+	}
+	if got, want := LogBeta(0.5, 0.5), math.Log(math.Pi); !near(got, want, 1e-14) {
+		z.Errorf("LogBeta(0.5, 0.5) = %v, want %v", got, want)
+	}
+}
+
+func TestInverses(z *testing.T) {
+	ps := []float64{1e-10, 0.001, 0.05, 0.3, 0.5, 0.7, 0.95, 0.999}
+	for _, a := range []float64{0.1, 0.5, 1, 2.5, 10, 100} {
+		for _, p := range ps {
+			x := IncGammaPInv(a, p)
+			if got := IncGammaP(a, x); !near(got, p, 1e-9) {
+				z.Errorf("IncGammaP(%v, IncGammaPInv(%v, %v)) = %v", a, a, p, got)
+			}
+			for _, b := range []float64{0.2, 1, 3.5, 50} {
+				x := IncBetaInv(a, b, p)
+				if 1-x < 1e-12 {
+					// Too close to 1 to be resolved in floating point.
+					continue
+				}
+				if got := IncBeta(a, b, x); !near(got, p, 1e-9) {
+					z.Errorf("IncBeta(%v, %v, IncBetaInv(%v, %v, %v)) = %v", a, b, a, b, p, got)
+				}
+			}
+		}
+	}
+
+	if x := IncGammaPInv(1, 1); !math.IsInf(x, 1) {
+		z.Errorf("IncGammaPInv(1, 1) = %v, want +Inf", x)
+	}
+	if x := IncBetaInv(1, 1, 0.25); !near(x, 0.25, 1e-14) {
+		z.Errorf("IncBetaInv(1, 1, 0.25) = %v, want 0.25", x)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package statutil
+
+import "math"
+
+// StudentTPDF returns the probability density of Student's t distribution
+// with nu degrees of freedom at t.
+func StudentTPDF(t, nu float64) float64 {
+	if !(nu > 0) {
+		return math.NaN()
+	}
+	a, _ := math.Lgamma((nu + 1) / 2)
+	b, _ := math.Lgamma(nu / 2)
+	return math.Exp(a-b-0.5*math.Log(nu*math.Pi)) * math.Pow(1+t*t/nu, -(nu+1)/2)
+}
+
+// StudentTCDF returns the cumulative probability of Student's t distribution
+// with nu degrees of freedom at t.
+func StudentTCDF(t, nu float64) float64 {
+	switch {
+	case !(nu > 0) || math.IsNaN(t):
+		return math.NaN()
+	case math.IsInf(nu, 1):
+		return NormalCDF(t)
+	}
+
+	// The tail probability P(T > |t|) is I_x(nu/2, 1/2) / 2. Near the
+	// center x is close to 1, so the symmetric form P(|T| < |t|) =
+	// I_{1-x}(1/2, nu/2) is used there instead to retain precision.
+	var tail float64
+	if t*t < nu {
+		tail = 0.5 - 0.5*IncBeta(0.5, nu/2, t*t/(nu+t*t))
+	} else {
+		tail = 0.5 * IncBeta(nu/2, 0.5, nu/(nu+t*t))
+	}
+	if t > 0 {
+		return 1 - tail
+	}
+	return tail
+}
+
+// StudentTQuantile returns the p-quantile of Student's t distribution
+// with nu degrees of freedom.
+//
+// If p is 0 or 1, -∞ or +∞ is returned; if p is not in [0, 1], NaN is returned.
+func StudentTQuantile(p, nu float64) float64 {
+	switch {
+	case !(nu > 0) || !(0 <= p && p <= 1):
+		return math.NaN()
+	case p == 0:
+		return math.Inf(-1)
+	case p == 1:
+		return math.Inf(1)
+	case p == 0.5:
+		return 0
+	case math.IsInf(nu, 1):
+		return NormalQuantile(p)
+	case p < 0.5:
+		// The distribution is symmetric.
+		return -StudentTQuantile(1-p, nu)
+	}
+
+	// Invert the relation between the tail probability and the
+	// incomplete beta function used by StudentTCDF. When the tail is
+	// large, the symmetric form avoids cancellation in 1-x.
+	q := 2 * (1 - p)
+	if q < 0.5 {
+		x := IncBetaInv(nu/2, 0.5, q)
+		return math.Sqrt(nu * (1 - x) / x)
+	}
+	y := IncBetaInv(0.5, nu/2, 1-q)
+	return math.Sqrt(nu * y / (1 - y))
+}
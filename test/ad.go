@@ -0,0 +1,94 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package test
+
+import (
+	"math"
+	"sort"
+
+	"github.com/goulash/stat"
+	"github.com/goulash/stat/dist"
+)
+
+// AndersonDarlingTest performs the Anderson-Darling test, which compares
+// the empirical distribution of s with the continuous distribution d.
+// Compared to the Kolmogorov-Smirnov test, it gives more weight to the
+// tails of the distribution.
+//
+// The statistic A² is the weighted squared distance between the two
+// distribution functions, and the p-value is the probability of a distance
+// of at least A² if s comes from d. The p-value assumes that the parameters
+// of d were not estimated from s.
+//
+// If a value lies outside the support of d, the statistic is +Inf and the
+// p-value is 0.
+func AndersonDarlingTest(s stat.Series, d dist.DistP, alpha float64) Result {
+	n := len(s)
+	if n == 0 || d == nil {
+		panic("invalid values submitted")
+	}
+
+	t := s.Copy()
+	sort.Float64s(t)
+	us := make([]float64, n)
+	for i, x := range t {
+		us[i] = d.P(x)
+	}
+
+	var sum float64
+	for i, u := range us {
+		sum += float64(2*i+1) * (math.Log(u) + math.Log1p(-us[n-1-i]))
+	}
+	pvalue := func(A2 float64) float64 {
+		return 1 - andersonDarlingCDF(n, A2)
+	}
+	A2 := -float64(n) - sum/float64(n)
+	if math.IsInf(A2, 0) || math.IsNaN(A2) {
+		A2 = math.Inf(1)
+	}
+	r := newResult(A2, pvalue(A2), alpha)
+	r.Critical = critical(pvalue, alpha, 0, 100)
+	return r
+}
+
+// andersonDarlingCDF returns the probability that the statistic for n
+// values is less than z, using the approximation of Marsaglia and Marsaglia,
+// "Evaluating the Anderson-Darling Distribution" (2004).
+func andersonDarlingCDF(n int, z float64) float64 {
+	if z <= 0 {
+		return 0
+	} else if math.IsInf(z, 1) {
+		return 1
+	}
+	x := andersonDarlingInf(z)
+	return math.Min(1, math.Max(0, x+andersonDarlingErr(n, x)))
+}
+
+// andersonDarlingInf returns the asymptotic distribution function.
+func andersonDarlingInf(z float64) float64 {
+	if z < 2 {
+		return math.Exp(-1.2337141/z) / math.Sqrt(z) *
+			(2.00012 + (0.247105-(0.0649821-(0.0347962-(0.011672-0.00168691*z)*z)*z)*z)*z)
+	}
+	return math.Exp(-math.Exp(1.0776 - (2.30695-(0.43424-(0.082433-(0.008056-0.0003146*z)*z)*z)*z)*z))
+}
+
+// andersonDarlingErr returns the correction of the asymptotic distribution
+// function x for n values.
+func andersonDarlingErr(n int, x float64) float64 {
+	fn := float64(n)
+	if x > 0.8 {
+		return (-130.2137 + (745.2337-(1705.091-(1950.646-(1116.360-255.7844*x)*x)*x)*x)*x) / fn
+	}
+	c := 0.01265 + 0.1757/fn
+	if x < c {
+		t := x / c
+		t = math.Sqrt(t) * (1 - t) * (49*t - 102)
+		return t * (0.0037/(fn*fn) + 0.00078/fn + 0.00006) / fn
+	}
+	t := (x - c) / (0.8 - c)
+	t = -0.00022633 + (6.54034-(14.6538-(14.458-(8.259-1.91864*t)*t)*t)*t)*t
+	return t * (0.04213/fn + 0.01365/(fn*fn))
+}
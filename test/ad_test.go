@@ -0,0 +1,63 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/goulash/stat"
+	"github.com/goulash/stat/dist"
+)
+
+func TestAndersonDarlingCDF(z *testing.T) {
+	// The asymptotic critical values, and critical values for n = 5
+	// estimated by simulation.
+	tests := []struct {
+		n    int
+		z    float64
+		want float64
+		eps  float64
+	}{
+		{100000, 1.933, 0.90, 1e-3},
+		{100000, 2.492, 0.95, 1e-3},
+		{100000, 3.857, 0.99, 1e-3},
+		{5, 0.7649, 0.50, 3e-3},
+		{5, 1.9530, 0.90, 3e-3},
+		{5, 2.5356, 0.95, 3e-3},
+		{5, 3.9650, 0.99, 3e-3},
+	}
+	for _, t := range tests {
+		if got := andersonDarlingCDF(t.n, t.z); math.Abs(got-t.want) > t.eps {
+			z.Errorf("andersonDarlingCDF(%d, %v) = %v, want %v", t.n, t.z, got, t.want)
+		}
+	}
+}
+
+func TestAndersonDarlingTest(z *testing.T) {
+	src := rand.NewSource(3)
+	for _, n := range []int{10, 100, 5000} {
+		xs := sample(dist.NewExponential(src, 3), n)
+		if r := AndersonDarlingTest(xs, dist.NewExponential(src, 3), 0.01); r.Reject || r.Statistic >= r.Critical {
+			z.Errorf("AndersonDarlingTest(exponential, n=%d) = %v, want no rejection", n, r)
+		}
+	}
+
+	xs := sample(dist.NewNormal(src, 0, 1), 500)
+	if r := AndersonDarlingTest(xs, dist.NewNormal(src, 0, 1.3), 0.01); !r.Reject || r.PValue > 1e-3 {
+		z.Errorf("AndersonDarlingTest(normal vs wider normal) = %v, want p < 1e-3", r)
+	}
+
+	// The asymptotic critical value at 5% is 2.492.
+	if r := AndersonDarlingTest(xs, dist.NewNormal(src, 0, 1), 0.05); math.Abs(r.Critical-2.492) > 0.01 {
+		z.Errorf("AndersonDarlingTest: critical value %v, want 2.492", r.Critical)
+	}
+
+	r := AndersonDarlingTest(stat.Series{0.5, 2}, dist.NewUniform(src, 0, 1), 0.05)
+	if !math.IsInf(r.Statistic, 1) || r.PValue != 0 || !r.Reject {
+		z.Errorf("AndersonDarlingTest outside support = %v, want +Inf, p = 0", r)
+	}
+}
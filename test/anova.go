@@ -0,0 +1,294 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package test
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/goulash/stat"
+	"github.com/goulash/stat/dist"
+)
+
+// AnovaRow is a row of an ANOVA table.
+//
+// For the residual and total rows, F and PValue are NaN, as is MS
+// for the total row.
+type AnovaRow struct {
+	Source string
+	SS     float64 // sum of squares
+	DF     float64 // degrees of freedom
+	MS     float64 // mean square, SS/DF
+	F      float64 // MS divided by the residual MS
+	PValue float64
+}
+
+// AnovaTable is the result of an analysis of variance. It contains a
+// row for each effect, followed by the Residual and Total rows.
+type AnovaTable []AnovaRow
+
+// Row returns the row of the source, and whether it exists.
+func (t AnovaTable) Row(source string) (AnovaRow, bool) {
+	for _, r := range t {
+		if r.Source == source {
+			return r, true
+		}
+	}
+	return AnovaRow{}, false
+}
+
+// Test returns the F test of the hypothesis that the effect of source
+// is zero, at the significance level alpha. The degrees of freedom of
+// the result are those of the effect, the critical value is that of the
+// F distribution, and the effect size is the partial η², SS/(SS+SSres).
+//
+// If the effect and the residual both have a sum of squares of zero, such
+// as when all values are the same, F is undefined, and the p-value is NaN
+// with a warning. If source is not an effect in the table, Test panics.
+func (t AnovaTable) Test(source string, alpha float64) Result {
+	r, ok := t.Row(source)
+	if !ok || source == "Residual" || source == "Total" {
+		panic("unknown effect: " + source)
+	}
+	res, _ := t.Row("Residual")
+
+	f := dist.NewF(nil, r.DF, res.DF)
+	result := newResult(r.F, r.PValue, alpha)
+	result.DF = r.DF
+	result.Critical = f.Q(1 - alpha)
+	result.EffectSize = r.SS / (r.SS + res.SS)
+	if math.IsNaN(r.F) {
+		result.warnf("F of %s is undefined, as its mean square and that of the residual are zero", source)
+	}
+	return result
+}
+
+func (t AnovaTable) String() string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%-12s %12s %6s %12s %10s %10s\n", "Source", "SS", "DF", "MS", "F", "P")
+	for _, r := range t {
+		fmt.Fprintf(&buf, "%-12s %12.6g %6g", r.Source, r.SS, r.DF)
+		if !math.IsNaN(r.MS) {
+			fmt.Fprintf(&buf, " %12.6g", r.MS)
+		}
+		if !math.IsNaN(r.F) {
+			fmt.Fprintf(&buf, " %10.4g %10.4g", r.F, r.PValue)
+		}
+		buf.WriteRune('\n')
+	}
+	return buf.String()
+}
+
+// newAnovaTable returns the table for the effects, whose F statistics
+// and p-values are calculated against the residual.
+func newAnovaTable(effects []AnovaRow, ssRes, dfRes float64) AnovaTable {
+	msRes := ssRes / dfRes
+	t := make(AnovaTable, 0, len(effects)+2)
+	ss, df := ssRes, dfRes
+	for _, e := range effects {
+		e.MS = e.SS / e.DF
+		e.F = e.MS / msRes
+		e.PValue = dist.NewF(nil, e.DF, dfRes).Survival(e.F)
+		t = append(t, e)
+		ss += e.SS
+		df += e.DF
+	}
+	nan := math.NaN()
+	t = append(t, AnovaRow{"Residual", ssRes, dfRes, msRes, nan, nan})
+	return append(t, AnovaRow{"Total", ss, df, nan, nan, nan})
+}
+
+// OneWayAnova performs the one-way analysis of variance of the groups,
+// which tests whether the groups have the same mean. The effect of the
+// groups is called "Groups" in the table. The groups need not have the
+// same size.
+//
+// The values are assumed to be independent and normally distributed
+// with the same variance in every group. If this is doubtful, consider
+// KruskalWallisTest instead. With two groups, this is the same as
+// PooledTTest.
+func OneWayAnova(groups ...stat.Series) AnovaTable {
+	k := len(groups)
+	if k < 2 {
+		panic("at least two groups are required")
+	}
+
+	var n int
+	var sum float64
+	for _, g := range groups {
+		if len(g) == 0 {
+			panic("groups must not be empty")
+		}
+		n += len(g)
+		for _, x := range g {
+			sum += x
+		}
+	}
+	if n == k {
+		panic("at least one group must have more than one value")
+	}
+	mean := sum / float64(n)
+
+	var ssGroups, ssRes float64
+	for _, g := range groups {
+		m := g.Mean()
+		ssGroups += float64(len(g)) * (m - mean) * (m - mean)
+		for _, x := range g {
+			ssRes += (x - m) * (x - m)
+		}
+	}
+
+	effects := []AnovaRow{{Source: "Groups", SS: ssGroups, DF: float64(k - 1)}}
+	return newAnovaTable(effects, ssRes, float64(n-k))
+}
+
+// OneWayAnovaMap is like OneWayAnova, but takes the groups by name, such
+// as the levels of a factor of an experiment. The name of the factor is
+// used as the source of its effect in the table, instead of "Groups".
+func OneWayAnovaMap(factor string, groups map[string]stat.Series) AnovaTable {
+	if factor == "" || factor == "Residual" || factor == "Total" {
+		panic("invalid factor name: " + factor)
+	}
+
+	// Sort the names, so that the result does not depend on the order
+	// of iteration, not even in the last bits.
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	gs := make([]stat.Series, len(names))
+	for i, name := range names {
+		gs[i] = groups[name]
+	}
+
+	t := OneWayAnova(gs...)
+	t[0].Source = factor
+	return t
+}
+
+// Design is a two-way factorial design, in which values are observed
+// for every combination of the levels of two factors, A and B.
+//
+// For example, the factors could be the scheduler and the load level of
+// a simulation, and each value the result of one replication.
+type Design struct {
+	A, B string // names of the factors
+
+	as    []string // levels of A in order of appearance
+	bs    []string // levels of B in order of appearance
+	cells map[[2]string]stat.Series
+}
+
+// NewDesign returns an empty design with factors named a and b.
+// These names are used as the sources in the ANOVA table.
+func NewDesign(a, b string) *Design {
+	if a == "" || b == "" || a == b {
+		panic("factors must have distinct names")
+	}
+	return &Design{A: a, B: b, cells: make(map[[2]string]stat.Series)}
+}
+
+// Add adds the values xs that were observed at level a of factor A
+// and level b of factor B.
+func (d *Design) Add(a, b string, xs ...float64) {
+	if !contains(d.as, a) {
+		d.as = append(d.as, a)
+	}
+	if !contains(d.bs, b) {
+		d.bs = append(d.bs, b)
+	}
+	key := [2]string{a, b}
+	d.cells[key] = append(d.cells[key], xs...)
+}
+
+// Levels returns the levels of the factors A and B.
+func (d *Design) Levels() (as, bs []string) {
+	as = append([]string(nil), d.as...)
+	bs = append([]string(nil), d.bs...)
+	return as, bs
+}
+
+// Cell returns the values observed at level a of A and level b of B.
+func (d *Design) Cell(a, b string) stat.Series {
+	return d.cells[[2]string{a, b}].Copy()
+}
+
+func contains(xs []string, x string) bool {
+	for _, y := range xs {
+		if x == y {
+			return true
+		}
+	}
+	return false
+}
+
+// TwoWayAnova performs the two-way analysis of variance of the design,
+// with interaction. The table contains the effects of factor A, factor B,
+// and their interaction, called "A:B" after the names of the factors.
+//
+// The design must be balanced: every combination of levels must have the
+// same number of values, and at least two, so that the interaction can be
+// separated from the residual. The assumptions are the same as those of
+// OneWayAnova.
+func TwoWayAnova(d *Design) AnovaTable {
+	a, b := len(d.as), len(d.bs)
+	if a < 2 || b < 2 {
+		panic("each factor must have at least two levels")
+	}
+	r := len(d.cells[[2]string{d.as[0], d.bs[0]}])
+	for _, la := range d.as {
+		for _, lb := range d.bs {
+			if len(d.cells[[2]string{la, lb}]) != r {
+				panic("design must be balanced")
+			}
+		}
+	}
+	if r < 2 {
+		panic("each cell must have at least two values")
+	}
+
+	// Cell, row, and column means.
+	cell := make([][]float64, a)
+	ma := make([]float64, a)
+	mb := make([]float64, b)
+	var mean float64
+	for i, la := range d.as {
+		cell[i] = make([]float64, b)
+		for j, lb := range d.bs {
+			m := d.cells[[2]string{la, lb}].Mean()
+			cell[i][j] = m
+			ma[i] += m / float64(b)
+			mb[j] += m / float64(a)
+			mean += m / float64(a*b)
+		}
+	}
+
+	var ssA, ssB, ssAB, ssRes float64
+	for i := range ma {
+		ssA += (ma[i] - mean) * (ma[i] - mean)
+	}
+	for j := range mb {
+		ssB += (mb[j] - mean) * (mb[j] - mean)
+	}
+	for i, la := range d.as {
+		for j, lb := range d.bs {
+			e := cell[i][j] - ma[i] - mb[j] + mean
+			ssAB += e * e
+			for _, x := range d.cells[[2]string{la, lb}] {
+				ssRes += (x - cell[i][j]) * (x - cell[i][j])
+			}
+		}
+	}
+	fr := float64(r)
+	effects := []AnovaRow{
+		{Source: d.A, SS: fr * float64(b) * ssA, DF: float64(a - 1)},
+		{Source: d.B, SS: fr * float64(a) * ssB, DF: float64(b - 1)},
+		{Source: d.A + ":" + d.B, SS: fr * ssAB, DF: float64((a - 1) * (b - 1))},
+	}
+	return newAnovaTable(effects, ssRes, float64(a*b*(r-1)))
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/goulash/stat"
+	"github.com/goulash/stat/dist"
+)
+
+func TestOneWayAnova(z *testing.T) {
+	near := func(a, b float64) bool { return math.Abs(a-b) <= 1e-9*math.Max(1, math.Abs(b)) }
+
+	// With two groups, F is the square of the pooled t statistic.
+	t := OneWayAnova(sleep1, sleep2)
+	r := PooledTTest(sleep1, sleep2, TwoSided, 0.05)
+	g, _ := t.Row("Groups")
+	if !near(g.F, r.Statistic*r.Statistic) || !near(g.PValue, r.PValue) || g.DF != 1 {
+		z.Errorf("OneWayAnova(sleep) = %v, want F = %v, p = %v", t, r.Statistic*r.Statistic, r.PValue)
+	}
+
+	res, _ := t.Row("Residual")
+	tot, _ := t.Row("Total")
+	all := append(sleep1.Copy(), sleep2...)
+	if !near(tot.SS, all.Var()*float64(len(all)-1)) || !near(tot.SS, g.SS+res.SS) || tot.DF != 19 || res.DF != 18 {
+		z.Errorf("OneWayAnova(sleep): inconsistent table\n%v", t)
+	}
+
+	ft := t.Test("Groups", 0.05)
+	if ft.Statistic != g.F || ft.PValue != g.PValue || ft.Reject {
+		z.Errorf("OneWayAnova(sleep).Test = %v, want F = %v, p = %v", ft, g.F, g.PValue)
+	}
+	if want := dist.NewF(nil, 1, 18).Q(0.95); !near(ft.Critical, want) {
+		z.Errorf("OneWayAnova(sleep).Test: critical value %v, want %v", ft.Critical, want)
+	}
+	if want := g.SS / (g.SS + res.SS); !near(ft.EffectSize, want) {
+		z.Errorf("OneWayAnova(sleep).Test: effect size %v, want %v", ft.EffectSize, want)
+	}
+
+	m := OneWayAnovaMap("drug", map[string]stat.Series{"1": sleep1, "2": sleep2})
+	if d, ok := m.Row("drug"); !ok || d.F != g.F || d.PValue != g.PValue || len(m) != 3 {
+		z.Errorf("OneWayAnovaMap(sleep) = %v, want the same as OneWayAnova with source drug", m)
+	}
+
+	// Without any variation, F is undefined.
+	c := OneWayAnova(stat.Series{1, 1}, stat.Series{1, 1, 1})
+	ct := c.Test("Groups", 0.05)
+	if !math.IsNaN(ct.PValue) || ct.Reject || len(ct.Warnings) != 1 {
+		z.Errorf("OneWayAnova(constant).Test = %v, want a NaN p-value and a warning", ct)
+	}
+}
+
+func TestTwoWayAnova(z *testing.T) {
+	// The warpbreaks data set: the number of breaks in yarn for two
+	// types of wool and three levels of tension.
+	d := NewDesign("wool", "tension")
+	d.Add("A", "L", 26, 30, 54, 25, 70, 52, 51, 26, 67)
+	d.Add("A", "M", 18, 21, 29, 17, 12, 18, 35, 30, 36)
+	d.Add("A", "H", 36, 21, 24, 18, 10, 43, 28, 15, 26)
+	d.Add("B", "L", 27, 14, 29, 19, 29, 31, 41, 20, 44)
+	d.Add("B", "M", 42, 26, 19, 16, 39, 28, 21, 39, 29)
+	d.Add("B", "H", 20, 21, 24, 17, 13, 15, 15, 16, 28)
+
+	t := TwoWayAnova(d)
+	tests := []struct {
+		source string
+		ss, df float64
+		f, p   float64
+	}{
+		{"wool", 450.66666666666674, 1, 3.765288361118634, 0.05821297595954855},
+		{"tension", 2034.259259259258, 2, 8.498046648358022, 0.0006926209367083088},
+		{"wool:tension", 1002.7777777777794, 2, 4.189068966851051, 0.02104419072782923},
+		{"Residual", 5745.1111111111095, 48, math.NaN(), math.NaN()},
+		{"Total", 9232.814814814814, 53, math.NaN(), math.NaN()},
+	}
+	near := func(a, b float64) bool {
+		if math.IsNaN(b) {
+			return math.IsNaN(a)
+		}
+		return math.Abs(a-b) <= 1e-9*math.Max(1, math.Abs(b))
+	}
+	for _, e := range tests {
+		r, ok := t.Row(e.source)
+		if !ok || !near(r.SS, e.ss) || r.DF != e.df || !near(r.F, e.f) || !near(r.PValue, e.p) {
+			z.Errorf("TwoWayAnova: row %s = %+v, want SS = %v, DF = %v, F = %v, p = %v", e.source, r, e.ss, e.df, e.f, e.p)
+		}
+	}
+	if r := t.Test("tension", 0.01); !r.Reject || r.DF != 2 {
+		z.Errorf("TwoWayAnova.Test(tension) = %v, want rejection with 2 degrees of freedom", r)
+	}
+	if s := t.String(); !strings.Contains(s, "wool:tension") || strings.Count(s, "\n") != 6 {
+		z.Errorf("TwoWayAnova.String() = %q, want a header and five rows", s)
+	}
+
+	as, bs := d.Levels()
+	if len(as) != 2 || len(bs) != 3 || as[0] != "A" || bs[2] != "H" {
+		z.Errorf("Design.Levels() = %v, %v, want [A B], [L M H]", as, bs)
+	}
+
+	unbalanced := NewDesign("a", "b")
+	unbalanced.Add("1", "1", 1, 2)
+	unbalanced.Add("1", "2", 1, 2)
+	unbalanced.Add("2", "1", 1, 2)
+	unbalanced.Add("2", "2", 1, 2, 3)
+	func() {
+		defer func() {
+			if recover() == nil {
+				z.Errorf("TwoWayAnova of an unbalanced design should panic")
+			}
+		}()
+		TwoWayAnova(unbalanced)
+	}()
+}
@@ -0,0 +1,294 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package test
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+
+	"github.com/goulash/stat/statutil"
+)
+
+// ContingencyTable counts how often each combination of the categories
+// of two variables was observed. The rows are the categories of the first
+// variable, the columns those of the second, both in order of appearance.
+//
+// The zero value is an empty table ready to use.
+type ContingencyTable struct {
+	rows   []string
+	cols   []string
+	counts map[[2]string]int
+	n      int
+}
+
+// CrossTab returns the table of the paired observations xs[i] and ys[i].
+func CrossTab(xs, ys []string) *ContingencyTable {
+	if len(xs) != len(ys) {
+		panic("observations must be paired")
+	}
+	t := &ContingencyTable{}
+	for i := range xs {
+		t.Add(xs[i], ys[i])
+	}
+	return t
+}
+
+// Add adds an observation of category row of the first variable and
+// category col of the second.
+func (t *ContingencyTable) Add(row, col string) {
+	t.AddN(row, col, 1)
+}
+
+// AddN adds n observations of category row and category col.
+//
+// If n is zero, nothing is added, and in particular no category is created,
+// as categories that were never observed would give undefined expected
+// counts. A negative n panics.
+func (t *ContingencyTable) AddN(row, col string, n int) {
+	if n < 0 {
+		panic("count must not be negative")
+	} else if n == 0 {
+		return
+	}
+	if t.counts == nil {
+		t.counts = make(map[[2]string]int)
+	}
+	if !contains(t.rows, row) {
+		t.rows = append(t.rows, row)
+	}
+	if !contains(t.cols, col) {
+		t.cols = append(t.cols, col)
+	}
+	t.counts[[2]string{row, col}] += n
+	t.n += n
+}
+
+// Rows returns the categories of the first variable.
+func (t *ContingencyTable) Rows() []string { return append([]string(nil), t.rows...) }
+
+// Cols returns the categories of the second variable.
+func (t *ContingencyTable) Cols() []string { return append([]string(nil), t.cols...) }
+
+// N returns the total number of observations.
+func (t *ContingencyTable) N() int { return t.n }
+
+// Count returns the number of observations of category row and category col.
+func (t *ContingencyTable) Count(row, col string) int {
+	return t.counts[[2]string{row, col}]
+}
+
+// Counts returns the counts as a matrix, indexed like Rows and Cols.
+func (t *ContingencyTable) Counts() [][]int {
+	m := make([][]int, len(t.rows))
+	for i, r := range t.rows {
+		m[i] = make([]int, len(t.cols))
+		for j, c := range t.cols {
+			m[i][j] = t.Count(r, c)
+		}
+	}
+	return m
+}
+
+func (t *ContingencyTable) String() string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%10s", "")
+	for _, c := range t.cols {
+		fmt.Fprintf(&buf, " %10s", c)
+	}
+	buf.WriteRune('\n')
+	for _, r := range t.rows {
+		fmt.Fprintf(&buf, "%10s", r)
+		for _, c := range t.cols {
+			fmt.Fprintf(&buf, " %10d", t.Count(r, c))
+		}
+		buf.WriteRune('\n')
+	}
+	return buf.String()
+}
+
+// expected returns the expected counts under independence,
+// the product of the row and column sums divided by N.
+func (t *ContingencyTable) expected() [][]float64 {
+	rs := make([]float64, len(t.rows))
+	cs := make([]float64, len(t.cols))
+	for i, r := range t.rows {
+		for j, c := range t.cols {
+			k := float64(t.Count(r, c))
+			rs[i] += k
+			cs[j] += k
+		}
+	}
+	e := make([][]float64, len(rs))
+	for i := range rs {
+		e[i] = make([]float64, len(cs))
+		for j := range cs {
+			e[i][j] = rs[i] * cs[j] / float64(t.n)
+		}
+	}
+	return e
+}
+
+func (t *ContingencyTable) check() {
+	if len(t.rows) < 2 || len(t.cols) < 2 {
+		panic("table must have at least two rows and two columns")
+	}
+}
+
+func (t *ContingencyTable) chi2() float64 {
+	var chi2 float64
+	for i, row := range t.expected() {
+		for j, e := range row {
+			d := float64(t.Count(t.rows[i], t.cols[j])) - e
+			chi2 += d * d / e
+		}
+	}
+	return chi2
+}
+
+func (t *ContingencyTable) df() int {
+	return (len(t.rows) - 1) * (len(t.cols) - 1)
+}
+
+// ChiSquaredTest performs Pearson's chi-squared test of the hypothesis
+// that the two variables are independent. The statistic is Σ(O-E)²/E,
+// where E are the counts expected under independence, and is compared
+// with the chi-squared distribution with (r-1)(c-1) degrees of freedom.
+// No continuity correction is applied.
+//
+// The effect size is Cramér's V. A warning is given if more than 20% of
+// the expected counts are less than 5, or any is less than 1, in which
+// case the approximation may be poor; for 2×2 tables FisherExactTest
+// can be used instead.
+func (t *ContingencyTable) ChiSquaredTest(alpha float64) Result {
+	t.check()
+	return t.result(t.chi2(), alpha)
+}
+
+// GTest performs the likelihood-ratio test of the hypothesis that the two
+// variables are independent. The statistic G = 2 Σ O ln(O/E) has the same
+// asymptotic distribution as that of ChiSquaredTest, and the result is
+// otherwise the same.
+func (t *ContingencyTable) GTest(alpha float64) Result {
+	t.check()
+	var g float64
+	for i, row := range t.expected() {
+		for j, e := range row {
+			if o := float64(t.Count(t.rows[i], t.cols[j])); o > 0 {
+				g += o * math.Log(o/e)
+			}
+		}
+	}
+	return t.result(2*g, alpha)
+}
+
+func (t *ContingencyTable) result(stat, alpha float64) Result {
+	df := t.df()
+	r := newResult(stat, ChiSquaredPValue(df, stat), alpha)
+	r.DF = float64(df)
+	r.Critical = ChiSquaredCritical(df, alpha)
+	r.EffectSize = t.CramersV()
+	warnExpected(&r, t.expected())
+	return r
+}
+
+// warnExpected adds a warning to r if the expected counts are too small
+// for the chi-squared approximation, according to the rule of Cochran.
+func warnExpected(r *Result, es [][]float64) {
+	var n, small int
+	min := math.Inf(1)
+	for _, row := range es {
+		for _, e := range row {
+			n++
+			if e < 5 {
+				small++
+			}
+			min = math.Min(min, e)
+		}
+	}
+	if min < 1 || float64(small) > 0.2*float64(n) {
+		r.warnf("%d of %d expected counts are less than 5, the smallest is %.3g", small, n, min)
+	}
+}
+
+// CramersV returns Cramér's V, √(χ²/(N(k-1))), where k is the smaller of
+// the number of rows and columns. It measures the strength of the
+// association between the variables, from 0 for none to 1 for complete.
+func (t *ContingencyTable) CramersV() float64 {
+	t.check()
+	k := len(t.rows)
+	if len(t.cols) < k {
+		k = len(t.cols)
+	}
+	return math.Sqrt(t.chi2() / (float64(t.n) * float64(k-1)))
+}
+
+// FisherExactTest performs Fisher's exact test of the hypothesis that the
+// two variables of a 2×2 table are independent. The p-value is computed
+// from the hypergeometric distribution of the count in the first cell,
+// given the row and column sums.
+//
+// If alt is Less or Greater, the alternative is that the odds ratio is less
+// or greater than one, that is, that the first cell has fewer or more
+// observations than expected. The two-sided p-value is the probability of
+// all tables that are at most as likely as the observed one.
+//
+// The statistic is the sample odds ratio ad/bc, and the effect size is
+// Cramér's V, which is the absolute value of the φ coefficient.
+func (t *ContingencyTable) FisherExactTest(alt Alternative, alpha float64) Result {
+	if len(t.rows) != 2 || len(t.cols) != 2 {
+		panic("table must have two rows and two columns")
+	}
+	m := t.Counts()
+	a, b, c, d := m[0][0], m[0][1], m[1][0], m[1][1]
+	r1, r2, c1 := a+b, c+d, a+c
+
+	lo, hi := c1-r2, r1
+	if lo < 0 {
+		lo = 0
+	}
+	if c1 < hi {
+		hi = c1
+	}
+	lp := func(x int) float64 {
+		return lchoose(r1, x) + lchoose(r2, c1-x) - lchoose(r1+r2, c1)
+	}
+
+	var p float64
+	switch alt {
+	case Less:
+		for x := lo; x <= a; x++ {
+			p += math.Exp(lp(x))
+		}
+	case Greater:
+		for x := a; x <= hi; x++ {
+			p += math.Exp(lp(x))
+		}
+	case TwoSided:
+		// Tables whose log-probability exceeds the observed one by at most
+		// an absolute tolerance count as equally likely, to guard against
+		// rounding.
+		obs := lp(a)
+		for x := lo; x <= hi; x++ {
+			if l := lp(x); l <= obs+1e-7 {
+				p += math.Exp(l)
+			}
+		}
+	default:
+		panic("unknown alternative")
+	}
+
+	odds := float64(a*d) / float64(b*c)
+	if a*d == 0 && b*c == 0 {
+		odds = math.NaN()
+	}
+	r := newResult(odds, math.Min(1, p), alpha)
+	r.EffectSize = t.CramersV()
+	return r
+}
+
+func lchoose(n, k int) float64 {
+	return statutil.LogGamma(float64(n+1)) - statutil.LogGamma(float64(k+1)) - statutil.LogGamma(float64(n-k+1))
+}
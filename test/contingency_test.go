@@ -0,0 +1,103 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package test
+
+import (
+	"math"
+	"testing"
+)
+
+func TestContingencyTable(z *testing.T) {
+	var t ContingencyTable
+	t.AddN("M", "A", 20)
+	t.AddN("M", "B", 15)
+	t.AddN("M", "C", 25)
+	t.AddN("F", "A", 30)
+	t.AddN("F", "B", 25)
+	t.AddN("F", "C", 10)
+	t.AddN("X", "D", 0)
+	if t.N() != 125 || t.Count("F", "B") != 25 || len(t.Rows()) != 2 || len(t.Cols()) != 3 {
+		z.Fatalf("ContingencyTable: got\n%v", &t)
+	}
+
+	near := func(a, b float64) bool { return math.Abs(a-b) <= 1e-9*math.Max(1, math.Abs(b)) }
+	r := t.ChiSquaredTest(0.01)
+	if !near(r.Statistic, 10.74576465201465) || !near(r.PValue, 0.00464073590080856) || r.DF != 2 || !r.Reject {
+		z.Errorf("ChiSquaredTest = %v, want χ² = 10.74576465201465, p = 0.00464073590080856", r)
+	}
+	if !near(r.EffectSize, 0.2931997906140405) || !near(t.CramersV(), r.EffectSize) || len(r.Warnings) != 0 {
+		z.Errorf("ChiSquaredTest: effect size %v, want Cramér's V 0.2931997906140405", r.EffectSize)
+	}
+	r = t.GTest(0.01)
+	if !near(r.Statistic, 10.98164481795816) || !near(r.PValue, 0.004124450793706865) || r.DF != 2 {
+		z.Errorf("GTest = %v, want G = 10.98164481795816, p = 0.004124450793706865", r)
+	}
+
+	// The table built from paired observations is the same.
+	var xs, ys []string
+	for _, row := range t.Rows() {
+		for _, col := range t.Cols() {
+			for i := 0; i < t.Count(row, col); i++ {
+				xs, ys = append(xs, row), append(ys, col)
+			}
+		}
+	}
+	u := CrossTab(xs, ys)
+	if u.String() != t.String() {
+		z.Errorf("CrossTab: got\n%v\nwant\n%v", u, &t)
+	}
+
+	small := CrossTab([]string{"a", "a", "b", "b", "b"}, []string{"x", "y", "x", "y", "y"})
+	if r := small.ChiSquaredTest(0.05); len(r.Warnings) != 1 {
+		z.Errorf("ChiSquaredTest of small table: warnings %q, want one", r.Warnings)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				z.Errorf("AddN with a negative count should panic")
+			}
+		}()
+		t.AddN("M", "A", -1)
+	}()
+}
+
+func TestFisherExactTest(z *testing.T) {
+	// Fisher's lady tasting tea.
+	var t ContingencyTable
+	t.AddN("milk", "milk", 3)
+	t.AddN("milk", "tea", 1)
+	t.AddN("tea", "milk", 1)
+	t.AddN("tea", "tea", 3)
+
+	tests := []struct {
+		alt Alternative
+		p   float64
+	}{
+		{TwoSided, 34.0 / 70},
+		{Greater, 17.0 / 70},
+		{Less, 69.0 / 70},
+	}
+	for _, e := range tests {
+		r := t.FisherExactTest(e.alt, 0.05)
+		if math.Abs(r.PValue-e.p) > 1e-12 || r.Statistic != 9 || r.Reject {
+			z.Errorf("FisherExactTest(%v) = %v, want odds ratio 9, p = %v", e.alt, r, e.p)
+		}
+		if math.Abs(r.EffectSize-0.5) > 1e-12 {
+			z.Errorf("FisherExactTest(%v): effect size %v, want 0.5", e.alt, r.EffectSize)
+		}
+	}
+}
+
+func TestChiSquaredGoodnessOfFit(z *testing.T) {
+	// Mendel's peas, expected in the ratio 9:3:3:1.
+	r := ChiSquaredGoodnessOfFit([]int{315, 108, 101, 32}, []float64{9, 3, 3, 1}, 0.05)
+	if math.Abs(r.Statistic-0.4700239808153477) > 1e-12 || math.Abs(r.PValue-0.925425895103616) > 1e-9 || r.DF != 3 || r.Reject {
+		z.Errorf("ChiSquaredGoodnessOfFit = %v, want χ² = 0.4700239808153477, p = 0.925425895103616", r)
+	}
+	if r := ChiSquaredGoodnessOfFit([]int{3, 1, 0}, []float64{0.5, 0.25, 0.25}, 0.05); len(r.Warnings) != 1 {
+		z.Errorf("ChiSquaredGoodnessOfFit of small counts: warnings %q, want one", r.Warnings)
+	}
+}
@@ -0,0 +1,248 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package test
+
+import (
+	"math"
+	"sort"
+
+	"github.com/goulash/stat"
+	"github.com/goulash/stat/dist"
+)
+
+// KolmogorovSmirnovTest performs the one-sample Kolmogorov-Smirnov test,
+// which compares the empirical distribution of s with the continuous
+// distribution d.
+//
+// The statistic D is the largest distance between the two distribution
+// functions, and the p-value is the probability of a distance of at least
+// D if s comes from d. For up to 1000 values the p-value is exact,
+// otherwise the asymptotic Kolmogorov distribution is used.
+func KolmogorovSmirnovTest(s stat.Series, d dist.DistP, alpha float64) Result {
+	n := len(s)
+	if n == 0 || d == nil {
+		panic("invalid values submitted")
+	}
+
+	t := s.Copy()
+	sort.Float64s(t)
+	var D float64
+	for i, x := range t {
+		f := d.P(x)
+		D = math.Max(D, math.Max(float64(i+1)/float64(n)-f, f-float64(i)/float64(n)))
+	}
+
+	pvalue := func(D float64) float64 {
+		if n <= 1000 {
+			return 1 - kolmogorovCDF(n, D)
+		}
+		return kolmogorovQ((math.Sqrt(float64(n)) + 0.12 + 0.11/math.Sqrt(float64(n))) * D)
+	}
+	r := newResult(D, pvalue(D), alpha)
+	r.Critical = critical(pvalue, alpha, 0, 1)
+	return r
+}
+
+// KolmogorovSmirnovTest2 performs the two-sample Kolmogorov-Smirnov test,
+// which compares the empirical distributions of s and t.
+//
+// The statistic D is the largest distance between the two distribution
+// functions, and the p-value is the probability of a distance of at least
+// D if s and t come from the same continuous distribution. If the product
+// of the sizes is at most 10000, the p-value is exact, otherwise the
+// asymptotic Kolmogorov distribution is used. The exact p-value assumes
+// that there are no ties; if there are, a warning is given.
+func KolmogorovSmirnovTest2(s, t stat.Series, alpha float64) Result {
+	n, m := len(s), len(t)
+	if n == 0 || m == 0 {
+		panic("invalid values submitted")
+	}
+
+	a, b := s.Copy(), t.Copy()
+	sort.Float64s(a)
+	sort.Float64s(b)
+	var D float64
+	var i, j int
+	var ties bool
+	for i < n && j < m {
+		// Advance past all values equal to the smallest one, so that
+		// ties do not create a spurious distance.
+		x := math.Min(a[i], b[j])
+		ties = ties || a[i] == b[j]
+		for i < n && a[i] == x {
+			i++
+		}
+		for j < m && b[j] == x {
+			j++
+		}
+		D = math.Max(D, math.Abs(float64(i)/float64(n)-float64(j)/float64(m)))
+	}
+
+	pvalue := func(D float64) float64 {
+		if n*m <= 10000 {
+			return ksTwoSampleExact(n, m, D)
+		}
+		ne := float64(n*m) / float64(n+m)
+		return kolmogorovQ((math.Sqrt(ne) + 0.12 + 0.11/math.Sqrt(ne)) * D)
+	}
+	r := newResult(D, pvalue(D), alpha)
+	r.Critical = critical(pvalue, alpha, 0, 1)
+	if ties {
+		r.warnf("the samples have values in common, so the p-value is approximate")
+	}
+	return r
+}
+
+// kolmogorovQ returns the complementary Kolmogorov distribution function,
+// 2 Σ (-1)^(j-1) exp(-2j²λ²), as in Numerical Recipes (probks).
+func kolmogorovQ(lambda float64) float64 {
+	if lambda < 0.2 {
+		return 1
+	}
+	var sum, prev float64
+	sign := 2.0
+	for j := 1; j <= 100; j++ {
+		term := sign * math.Exp(-2*float64(j*j)*lambda*lambda)
+		sum += term
+		if math.Abs(term) <= 1e-10*prev || math.Abs(term) <= 1e-16*sum {
+			return math.Min(1, math.Max(0, sum))
+		}
+		sign = -sign
+		prev = math.Abs(term)
+	}
+	return 1
+}
+
+// kolmogorovCDF returns the probability that the one-sample statistic
+// for n values is less than d, using the method of Marsaglia, Tsang,
+// and Wang, "Evaluating Kolmogorov's Distribution" (2003).
+func kolmogorovCDF(n int, d float64) float64 {
+	if d <= 0 {
+		return 0
+	} else if d >= 1 {
+		return 1
+	}
+	fn := float64(n)
+	if s := d * d * fn; s > 7.24 || (s > 3.76 && n > 99) {
+		// The right tail is accurate to about seven digits.
+		return 1 - 2*math.Exp(-(2.000071+0.331/math.Sqrt(fn)+1.409/fn)*s)
+	}
+
+	k := int(fn*d) + 1
+	m := 2*k - 1
+	h := float64(k) - fn*d
+	H := make([]float64, m*m)
+	for i := 0; i < m; i++ {
+		for j := 0; j < m; j++ {
+			if i-j+1 >= 0 {
+				H[i*m+j] = 1
+			}
+		}
+	}
+	for i := 0; i < m; i++ {
+		H[i*m] -= math.Pow(h, float64(i+1))
+		H[(m-1)*m+i] -= math.Pow(h, float64(m-i))
+	}
+	if 2*h-1 > 0 {
+		H[(m-1)*m] += math.Pow(2*h-1, float64(m))
+	}
+	for i := 0; i < m; i++ {
+		for j := 0; j < m; j++ {
+			for g := 2; g <= i-j+1; g++ {
+				H[i*m+j] /= float64(g)
+			}
+		}
+	}
+
+	Q, e := matPow(H, m, n)
+	s := Q[(k-1)*m+k-1]
+	for i := 1; i <= n; i++ {
+		s = s * float64(i) / fn
+		if s < 1e-140 {
+			s *= 1e140
+			e -= 140
+		}
+	}
+	return s * math.Pow(10, float64(e))
+}
+
+// matPow returns A^n for the m×m matrix A, as a matrix and a decimal
+// exponent, so that the result is V·10^e.
+func matPow(A []float64, m, n int) (V []float64, e int) {
+	if n == 1 {
+		V = make([]float64, len(A))
+		copy(V, A)
+		return V, 0
+	}
+	V, e = matPow(A, m, n/2)
+	B := matMul(V, V, m)
+	e *= 2
+	if n%2 == 0 {
+		V = B
+	} else {
+		V = matMul(A, B, m)
+	}
+	if V[(m/2)*m+m/2] > 1e140 {
+		for i := range V {
+			V[i] *= 1e-140
+		}
+		e += 140
+	}
+	return V, e
+}
+
+func matMul(A, B []float64, m int) []float64 {
+	C := make([]float64, m*m)
+	for i := 0; i < m; i++ {
+		for k := 0; k < m; k++ {
+			a := A[i*m+k]
+			if a == 0 {
+				continue
+			}
+			for j := 0; j < m; j++ {
+				C[i*m+j] += a * B[k*m+j]
+			}
+		}
+	}
+	return C
+}
+
+// ksTwoSampleExact returns the probability that the two-sample statistic
+// for samples of size n and m is at least d, by counting the lattice paths
+// from (0, 0) to (n, m) that stay strictly within distance d of the
+// diagonal, and dividing by the total number of paths.
+func ksTwoSampleExact(n, m int, d float64) float64 {
+	// Distances are compared as integers i·m - j·n against d·n·m, with
+	// a small tolerance for the rounding of d.
+	limit := d*float64(n)*float64(m) - 1e-7
+	inside := func(i, j int) bool {
+		return math.Abs(float64(i*m-j*n)) < limit
+	}
+
+	// u[j] is the number of paths to (i, j) divided by C(i+j, j), which
+	// keeps the values within [0, 1].
+	u := make([]float64, m+1)
+	u[0] = 1
+	for j := 1; j <= m; j++ {
+		if inside(0, j) {
+			u[j] = u[j-1]
+		} else {
+			u[j] = 0
+		}
+	}
+	for i := 1; i <= n; i++ {
+		if !inside(i, 0) {
+			u[0] = 0
+		}
+		for j := 1; j <= m; j++ {
+			if inside(i, j) {
+				u[j] = (u[j]*float64(i) + u[j-1]*float64(j)) / float64(i+j)
+			} else {
+				u[j] = 0
+			}
+		}
+	}
+	return math.Min(1, math.Max(0, 1-u[m]))
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/goulash/stat"
+	"github.com/goulash/stat/dist"
+)
+
+func sample(d dist.Continuous, n int) stat.Series {
+	xs := make(stat.Series, n)
+	for i := range xs {
+		xs[i] = d.Float64()
+	}
+	return xs
+}
+
+func TestKolmogorovCDF(z *testing.T) {
+	tests := []struct {
+		n    int
+		d    float64
+		want float64
+	}{
+		{1, 0.75, 0.5},
+		{1, 0.9, 0.8},
+		{5, 0.4, 0.6912},
+		{10, 0.274, 0.6284796154565043},
+		{20, 0.15, 0.29553284505571287},
+	}
+	for _, t := range tests {
+		if got := kolmogorovCDF(t.n, t.d); math.Abs(got-t.want) > 1e-12 {
+			z.Errorf("kolmogorovCDF(%d, %v) = %v, want %v", t.n, t.d, got, t.want)
+		}
+	}
+
+	// For large n the exact and asymptotic distributions nearly agree.
+	n := 1000
+	for _, d := range []float64{0.02, 0.03, 0.04, 0.05} {
+		want := 1 - kolmogorovQ((math.Sqrt(float64(n))+0.12+0.11/math.Sqrt(float64(n)))*d)
+		if got := kolmogorovCDF(n, d); math.Abs(got-want) > 5e-3 {
+			z.Errorf("kolmogorovCDF(%d, %v) = %v, want %v", n, d, got, want)
+		}
+	}
+}
+
+func TestKolmogorovSmirnovTest(z *testing.T) {
+	src := rand.NewSource(1)
+	for _, n := range []int{10, 100, 5000} {
+		xs := sample(dist.NewNormal(src, 1, 2), n)
+		r := KolmogorovSmirnovTest(xs, dist.NewNormal(src, 1, 2), 0.01)
+		if r.Reject || r.Statistic >= r.Critical {
+			z.Errorf("KolmogorovSmirnovTest(normal, n=%d) = %v, want no rejection", n, r)
+		}
+	}
+
+	xs := sample(dist.NewExponential(src, 1), 500)
+	r := KolmogorovSmirnovTest(xs, dist.NewNormal(src, 1, 1), 0.01)
+	if !r.Reject || r.PValue > 1e-6 || r.Statistic <= r.Critical {
+		z.Errorf("KolmogorovSmirnovTest(exponential vs normal) = %v, want rejection with p < 1e-6", r)
+	}
+
+	r = KolmogorovSmirnovTest(stat.Series{0.9, 0.1, 0.5}, dist.NewUniform(src, 0, 1), 0.05)
+	if want := 1.0/3 - 0.1; math.Abs(r.Statistic-want) > 1e-12 {
+		z.Errorf("KolmogorovSmirnovTest: D = %v, want %v", r.Statistic, want)
+	}
+	if !math.IsNaN(r.DF) || len(r.Warnings) != 0 {
+		z.Errorf("KolmogorovSmirnovTest: got %v, want no degrees of freedom and no warnings", r)
+	}
+
+	// Critical values from the tables of Miller (1956).
+	for _, t := range []struct {
+		n     int
+		alpha float64
+		want  float64
+	}{
+		{10, 0.05, 0.40925},
+		{20, 0.05, 0.29408},
+		{20, 0.01, 0.35241},
+	} {
+		xs := sample(dist.NewUniform(src, 0, 1), t.n)
+		if r := KolmogorovSmirnovTest(xs, dist.NewUniform(src, 0, 1), t.alpha); math.Abs(r.Critical-t.want) > 1e-4 {
+			z.Errorf("KolmogorovSmirnovTest(n=%d, alpha=%v): critical value %v, want %v", t.n, t.alpha, r.Critical, t.want)
+		}
+	}
+}
+
+// ksBrute returns the exact two-sample p-value by enumerating all ways
+// of choosing which of the n+m ranks belong to the first sample.
+func ksBrute(n, m int, d float64) float64 {
+	var count, total int
+	for mask := 0; mask < 1<<uint(n+m); mask++ {
+		var ones int
+		for b := mask; b > 0; b >>= 1 {
+			ones += b & 1
+		}
+		if ones != n {
+			continue
+		}
+		total++
+		var i, j int
+		var max float64
+		for k := 0; k < n+m; k++ {
+			if mask&(1<<uint(k)) != 0 {
+				i++
+			} else {
+				j++
+			}
+			max = math.Max(max, math.Abs(float64(i)/float64(n)-float64(j)/float64(m)))
+		}
+		if max >= d-1e-12 {
+			count++
+		}
+	}
+	return float64(count) / float64(total)
+}
+
+func TestKolmogorovSmirnovTest2(z *testing.T) {
+	for _, t := range []struct{ n, m int }{{1, 1}, {3, 4}, {5, 5}, {2, 9}, {6, 7}} {
+		for _, d := range []float64{0.2, 1.0 / 3, 0.5, 0.6, 1} {
+			if got, want := ksTwoSampleExact(t.n, t.m, d), ksBrute(t.n, t.m, d); math.Abs(got-want) > 1e-12 {
+				z.Errorf("ksTwoSampleExact(%d, %d, %v) = %v, want %v", t.n, t.m, d, got, want)
+			}
+		}
+	}
+
+	r := KolmogorovSmirnovTest2(stat.Series{1, 2, 3}, stat.Series{4, 5, 6, 7}, 0.05)
+	if r.Statistic != 1 || math.Abs(r.PValue-2.0/35) > 1e-12 || r.Reject || r.Critical != 1 {
+		z.Errorf("KolmogorovSmirnovTest2 = %v, want D = 1, p = %v, critical value 1", r, 2.0/35)
+	}
+	r = KolmogorovSmirnovTest2(stat.Series{1, 2, 2}, stat.Series{2, 2, 1}, 0.05)
+	if r.Statistic != 0 || r.PValue != 1 || len(r.Warnings) != 1 {
+		z.Errorf("KolmogorovSmirnovTest2 with ties = %v, want D = 0, p = 1, and a warning", r)
+	}
+
+	src := rand.NewSource(2)
+	for _, n := range []int{20, 300} {
+		xs := sample(dist.NewNormal(src, 0, 1), n)
+		ys := sample(dist.NewNormal(src, 0, 1), n+7)
+		if r := KolmogorovSmirnovTest2(xs, ys, 0.01); r.Reject {
+			z.Errorf("KolmogorovSmirnovTest2(normal, normal, n=%d) = %v, want no rejection", n, r)
+		}
+		ys = sample(dist.NewNormal(src, 2, 1), n+7)
+		if r := KolmogorovSmirnovTest2(xs, ys, 0.01); !r.Reject || r.PValue > 1e-3 {
+			z.Errorf("KolmogorovSmirnovTest2(normal, shifted normal, n=%d) = %v, want p < 1e-3", n, r)
+		}
+	}
+}
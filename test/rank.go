@@ -0,0 +1,337 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package test
+
+import (
+	"math"
+	"sort"
+
+	"github.com/goulash/stat"
+	"github.com/goulash/stat/statutil"
+)
+
+// exactLimit is the largest sample size for which the rank tests compute
+// exact p-values. Beyond it, the normal approximation is accurate.
+const exactLimit = 50
+
+// rank returns the ranks of xs, starting at 1, where tied values receive
+// the average of their ranks. It also returns the tie correction Σ(t³-t),
+// where t is the number of values in each group of ties.
+func rank(xs []float64) (ranks []float64, ties float64) {
+	idx := make([]int, len(xs))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.Slice(idx, func(i, j int) bool { return xs[idx[i]] < xs[idx[j]] })
+
+	ranks = make([]float64, len(xs))
+	for i := 0; i < len(idx); {
+		j := i + 1
+		for j < len(idx) && xs[idx[j]] == xs[idx[i]] {
+			j++
+		}
+		r := float64(i+j+1) / 2
+		for _, k := range idx[i:j] {
+			ranks[k] = r
+		}
+		t := float64(j - i)
+		ties += t*t*t - t
+		i = j
+	}
+	return ranks, ties
+}
+
+// normalPValue returns the p-value of the statistic x with the given mean
+// and standard deviation under the alternative a, using the normal
+// approximation with a continuity correction of 1/2.
+func normalPValue(x, mean, sd float64, a Alternative) float64 {
+	less := statutil.NormalCDF((x - mean + 0.5) / sd)
+	greater := 1 - statutil.NormalCDF((x-mean-0.5)/sd)
+	switch a {
+	case TwoSided:
+		return math.Min(1, 2*math.Min(less, greater))
+	case Less:
+		return less
+	case Greater:
+		return greater
+	default:
+		panic("unknown alternative")
+	}
+}
+
+// exactPValue returns the p-value of the integer statistic x under the
+// alternative a, where counts[k] is proportional to the probability of k.
+func exactPValue(x int, counts []float64, a Alternative) float64 {
+	var total, less, greater float64
+	for k, c := range counts {
+		total += c
+		if k <= x {
+			less += c
+		}
+		if k >= x {
+			greater += c
+		}
+	}
+	less, greater = less/total, greater/total
+	switch a {
+	case TwoSided:
+		return math.Min(1, 2*math.Min(less, greater))
+	case Less:
+		return less
+	case Greater:
+		return greater
+	default:
+		panic("unknown alternative")
+	}
+}
+
+// MannWhitneyTest performs the Mann-Whitney U test, also known as the
+// Wilcoxon rank-sum test, of the hypothesis that the values of s and t
+// come from the same distribution. It is sensitive to one distribution
+// tending to have larger values than the other. If alt is Less or
+// Greater, the alternative is that the values of s tend to be less or
+// greater than those of t.
+//
+// The statistic U is the number of pairs (s[i], t[j]) with s[i] > t[j],
+// where ties count one half. If both samples have at most 50 values and
+// there are no ties, the p-value is exact; otherwise the normal
+// approximation with tie and continuity correction is used.
+//
+// The effect size is the rank-biserial correlation 2U/(nm) - 1, which is
+// positive if the values of s tend to be greater than those of t.
+func MannWhitneyTest(s, t stat.Series, alt Alternative, alpha float64) Result {
+	n1, n2 := len(s), len(t)
+	if n1 == 0 || n2 == 0 {
+		panic("invalid values submitted")
+	}
+
+	all := make([]float64, 0, n1+n2)
+	all = append(append(all, s...), t...)
+	ranks, ties := rank(all)
+	var r1 float64
+	for _, r := range ranks[:n1] {
+		r1 += r
+	}
+	f1, f2 := float64(n1), float64(n2)
+	u := r1 - f1*(f1+1)/2
+
+	var p float64
+	if ties == 0 && n1 <= exactLimit && n2 <= exactLimit {
+		p = exactPValue(int(u), mannWhitneyCounts(n1, n2), alt)
+	} else {
+		n := f1 + f2
+		sd := math.Sqrt(f1 * f2 / 12 * (n + 1 - ties/(n*(n-1))))
+		p = normalPValue(u, f1*f2/2, sd, alt)
+	}
+
+	res := newResult(u, p, alpha)
+	res.EffectSize = 2*u/(f1*f2) - 1
+	return res
+}
+
+// mannWhitneyCounts returns the number of arrangements of samples of size
+// n and m for each value of U. These are the coefficients of the Gaussian
+// binomial coefficient [n+m choose n] in q, which is built up as the
+// product of (1-q^(m+i))/(1-q^i) for i from 1 to n.
+func mannWhitneyCounts(n, m int) []float64 {
+	c := make([]float64, n*m+1)
+	c[0] = 1
+	for i := 1; i <= n; i++ {
+		k := m + i
+		for u := len(c) - 1; u >= k; u-- {
+			c[u] -= c[u-k]
+		}
+		for u := i; u < len(c); u++ {
+			c[u] += c[u-i]
+		}
+	}
+	return c
+}
+
+// WilcoxonTest performs the Wilcoxon signed-rank test of the hypothesis
+// that the differences s[i]-t[i] of the pairs are symmetric about zero.
+// If alt is Less or Greater, the alternative is that the values of s tend
+// to be less or greater than their partners in t.
+//
+// Pairs with a difference of zero are dropped, with a warning. The
+// statistic V is the sum of the ranks of the absolute differences that
+// are positive. If there are at most 50 pairs and no ties, the p-value is
+// exact; otherwise the normal approximation with tie and continuity
+// correction is used.
+//
+// The effect size is the matched-pairs rank-biserial correlation, the
+// difference between the rank sums of the positive and negative differences
+// divided by their total.
+func WilcoxonTest(s, t stat.Series, alt Alternative, alpha float64) Result {
+	if len(s) != len(t) {
+		panic("series must have the same length")
+	}
+
+	var ds []float64
+	for i := range s {
+		if d := s[i] - t[i]; d != 0 {
+			ds = append(ds, d)
+		}
+	}
+	n := len(ds)
+	if n == 0 {
+		panic("at least one pair must differ")
+	}
+
+	abs := make([]float64, n)
+	for i, d := range ds {
+		abs[i] = math.Abs(d)
+	}
+	ranks, ties := rank(abs)
+	var v float64
+	for i, d := range ds {
+		if d > 0 {
+			v += ranks[i]
+		}
+	}
+	fn := float64(n)
+	total := fn * (fn + 1) / 2
+
+	var p float64
+	if ties == 0 && n <= exactLimit {
+		p = exactPValue(int(v), signedRankCounts(n), alt)
+	} else {
+		sd := math.Sqrt(fn*(fn+1)*(2*fn+1)/24 - ties/48)
+		p = normalPValue(v, total/2, sd, alt)
+	}
+
+	r := newResult(v, p, alpha)
+	r.EffectSize = (2*v - total) / total
+	if dropped := len(s) - n; dropped > 0 {
+		r.warnf("%d of %d pairs have no difference and were dropped", dropped, len(s))
+	}
+	return r
+}
+
+// signedRankCounts returns the number of subsets of {1, ..., n} for each
+// sum V. These are the coefficients of the product of (1+q^i).
+func signedRankCounts(n int) []float64 {
+	c := make([]float64, n*(n+1)/2+1)
+	c[0] = 1
+	for i := 1; i <= n; i++ {
+		for v := len(c) - 1; v >= i; v-- {
+			c[v] += c[v-i]
+		}
+	}
+	return c
+}
+
+// kruskalExactLimit is the largest number of arrangements of the ranks
+// into groups that KruskalWallisTest enumerates for an exact p-value.
+const kruskalExactLimit = 1e6
+
+// KruskalWallisTest performs the Kruskal-Wallis H test of the hypothesis
+// that all the groups come from the same distribution. It generalizes the
+// Mann-Whitney U test to more than two groups.
+//
+// The statistic H is computed from the rank sums of the groups, with a
+// correction for ties. If the number of ways to arrange the ranks into
+// groups is at most one million, the p-value is exact and computed by
+// enumerating them; otherwise it is approximated by the chi-squared
+// distribution with k-1 degrees of freedom, which is also reported
+// together with its critical value.
+//
+// The effect size is ε² = H/(N-1), where N is the total number of values.
+func KruskalWallisTest(alpha float64, groups ...stat.Series) Result {
+	k := len(groups)
+	if k < 2 {
+		panic("at least two groups are required")
+	}
+	var all []float64
+	sizes := make([]int, k)
+	for i, g := range groups {
+		if len(g) == 0 {
+			panic("groups must not be empty")
+		}
+		all = append(all, g...)
+		sizes[i] = len(g)
+	}
+	ranks, ties := rank(all)
+	n := float64(len(all))
+	if ties == n*n*n-n {
+		panic("all values are the same")
+	}
+
+	// h returns the statistic for the rank sums rs.
+	scale := 12 / (n * (n + 1)) / (1 - ties/(n*n*n-n))
+	h := func(rs []float64) float64 {
+		var sum float64
+		for i, r := range rs {
+			sum += r * r / float64(sizes[i])
+		}
+		return scale * (sum - n*(n+1)*(n+1)/4)
+	}
+
+	rs := make([]float64, k)
+	var j int
+	for i, size := range sizes {
+		for _, r := range ranks[j : j+size] {
+			rs[i] += r
+		}
+		j += size
+	}
+	H := h(rs)
+
+	df := k - 1
+	var r Result
+	if multinomial(sizes) <= kruskalExactLimit {
+		r = newResult(H, kruskalExact(ranks, sizes, H, h), alpha)
+	} else {
+		r = newResult(H, ChiSquaredPValue(df, H), alpha)
+		r.Critical = ChiSquaredCritical(df, alpha)
+	}
+	r.DF = float64(df)
+	r.EffectSize = H / (n - 1)
+	return r
+}
+
+// multinomial returns the number of ways to split Σsizes items into groups
+// of the given sizes.
+func multinomial(sizes []int) float64 {
+	var n int
+	lg := 0.0
+	for _, s := range sizes {
+		n += s
+		lg -= statutil.LogGamma(float64(s + 1))
+	}
+	return math.Exp(lg + statutil.LogGamma(float64(n+1)))
+}
+
+// kruskalExact returns the fraction of arrangements of the ranks into
+// groups of the given sizes whose statistic is at least H.
+func kruskalExact(ranks []float64, sizes []int, H float64, h func([]float64) float64) float64 {
+	rs := make([]float64, len(sizes))
+	left := make([]int, len(sizes))
+	copy(left, sizes)
+
+	var count, total float64
+	var assign func(i int)
+	assign = func(i int) {
+		if i == len(ranks) {
+			total++
+			if h(rs) >= H-1e-9*math.Max(1, H) {
+				count++
+			}
+			return
+		}
+		for g := range left {
+			if left[g] == 0 {
+				continue
+			}
+			left[g]--
+			rs[g] += ranks[i]
+			assign(i + 1)
+			rs[g] -= ranks[i]
+			left[g]++
+		}
+	}
+	assign(0)
+	return count / total
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/goulash/stat"
+	"github.com/goulash/stat/dist"
+)
+
+func TestRank(z *testing.T) {
+	ranks, ties := rank([]float64{3, 1, 2, 3, 2, 3})
+	want := []float64{5, 1, 2.5, 5, 2.5, 5}
+	for i := range want {
+		if ranks[i] != want[i] {
+			z.Errorf("rank: got %v, want %v", ranks, want)
+			break
+		}
+	}
+	if ties != 30 {
+		z.Errorf("rank: got tie correction %v, want 30", ties)
+	}
+}
+
+func TestMannWhitneyTest(z *testing.T) {
+	x := stat.Series{0.80, 0.83, 1.89, 1.04, 1.45, 1.38, 1.91, 1.64, 0.73, 1.46}
+	y := stat.Series{1.15, 0.88, 0.90, 0.74, 1.21}
+
+	r := MannWhitneyTest(x, y, Greater, 0.05)
+	if r.Statistic != 35 || math.Abs(r.PValue-0.1272061272061272) > 1e-12 || r.Reject {
+		z.Errorf("MannWhitneyTest(greater) = %v, want U = 35, p = 0.1272061272061272", r)
+	}
+	if math.Abs(r.EffectSize-0.4) > 1e-12 {
+		z.Errorf("MannWhitneyTest: effect size %v, want 0.4", r.EffectSize)
+	}
+	if r := MannWhitneyTest(x, y, Less, 0.05); math.Abs(r.PValue-0.8967698967698968) > 1e-12 {
+		z.Errorf("MannWhitneyTest(less) = %v, want p = 0.8967698967698968", r)
+	}
+	if r := MannWhitneyTest(x, y, TwoSided, 0.05); math.Abs(r.PValue-2*0.1272061272061272) > 1e-12 {
+		z.Errorf("MannWhitneyTest(two-sided) = %v, want p = %v", r, 2*0.1272061272061272)
+	}
+
+	// For large samples, the normal approximation is close to the exact value.
+	src := rand.NewSource(5)
+	a := sample(dist.NewExponential(src, 1), 60)
+	b := sample(dist.NewExponential(src, 1.5), 60)
+	r = MannWhitneyTest(a, b, TwoSided, 0.05)
+	if exact := exactPValue(int(r.Statistic), mannWhitneyCounts(60, 60), TwoSided); math.Abs(r.PValue-exact) > 2e-3 {
+		z.Errorf("MannWhitneyTest(n=60) = %v, want p close to %v", r, exact)
+	}
+}
+
+func TestWilcoxonTest(z *testing.T) {
+	x := stat.Series{1.83, 0.50, 1.62, 2.48, 1.68, 1.88, 1.55, 3.06, 1.30}
+	y := stat.Series{0.878, 0.647, 0.598, 2.05, 1.06, 1.29, 1.06, 3.14, 1.29}
+
+	r := WilcoxonTest(x, y, Greater, 0.05)
+	if r.Statistic != 40 || math.Abs(r.PValue-0.01953125) > 1e-12 || !r.Reject {
+		z.Errorf("WilcoxonTest(greater) = %v, want V = 40, p = 0.01953125", r)
+	}
+	if math.Abs(r.EffectSize-35.0/45) > 1e-12 || len(r.Warnings) != 0 {
+		z.Errorf("WilcoxonTest: effect size %v, want %v and no warnings", r.EffectSize, 35.0/45)
+	}
+	if r := WilcoxonTest(x, y, TwoSided, 0.05); math.Abs(r.PValue-0.0390625) > 1e-12 {
+		z.Errorf("WilcoxonTest(two-sided) = %v, want p = 0.0390625", r)
+	}
+
+	// Equal pairs are dropped, and ties use the normal approximation.
+	r = WilcoxonTest(stat.Series{1, 2, 3, 4, 5, 6}, stat.Series{1, 1, 2, 2, 3, 3}, Greater, 0.05)
+	if len(r.Warnings) != 1 || r.Statistic != 15 {
+		z.Errorf("WilcoxonTest with zeros = %v, want V = 15 and a warning", r)
+	}
+
+	// For large samples, the normal approximation is close to the exact value.
+	src := rand.NewSource(6)
+	a := sample(dist.NewNormal(src, 0.2, 1), 60)
+	b := sample(dist.NewNormal(src, 0, 1), 60)
+	r = WilcoxonTest(a, b, TwoSided, 0.05)
+	if exact := exactPValue(int(r.Statistic), signedRankCounts(60), TwoSided); math.Abs(r.PValue-exact) > 2e-3 {
+		z.Errorf("WilcoxonTest(n=60) = %v, want p close to %v", r, exact)
+	}
+}
+
+func TestKruskalWallisTest(z *testing.T) {
+	a := stat.Series{2.9, 3.0, 2.5, 2.6, 3.2}
+	b := stat.Series{3.8, 2.7, 4.0, 2.4}
+	c := stat.Series{2.8, 3.4, 3.7, 2.2, 2.0}
+
+	r := KruskalWallisTest(0.05, a, b, c)
+	if math.Abs(r.Statistic-0.7714285714285722) > 1e-12 || r.DF != 2 || r.Reject {
+		z.Errorf("KruskalWallisTest = %v, want H = 0.77143 with 2 degrees of freedom", r)
+	}
+	if math.Abs(r.PValue-0.7107733536304965) > 1e-12 {
+		z.Errorf("KruskalWallisTest: exact p = %v, want 0.7107733536304965", r.PValue)
+	}
+	if math.Abs(r.EffectSize-0.0593406593406594) > 1e-12 {
+		z.Errorf("KruskalWallisTest: effect size %v, want 0.0593406593406594", r.EffectSize)
+	}
+
+	// With two groups, the exact test is the same as the Mann-Whitney test.
+	x := stat.Series{0.80, 0.83, 1.89, 1.04, 1.45, 1.38, 1.91, 1.64, 0.73, 1.46}
+	y := stat.Series{1.15, 0.88, 0.90, 0.74, 1.21}
+	if p, want := KruskalWallisTest(0.05, x, y).PValue, MannWhitneyTest(x, y, TwoSided, 0.05).PValue; math.Abs(p-want) > 1e-12 {
+		z.Errorf("KruskalWallisTest of two groups: p = %v, want %v", p, want)
+	}
+
+	// Larger groups use the chi-squared approximation.
+	src := rand.NewSource(7)
+	gs := []stat.Series{
+		sample(dist.NewNormal(src, 0, 1), 10),
+		sample(dist.NewNormal(src, 0, 1), 10),
+		sample(dist.NewNormal(src, 2, 1), 10),
+	}
+	r = KruskalWallisTest(0.01, gs...)
+	if !r.Reject || r.PValue != ChiSquaredPValue(2, r.Statistic) || r.Critical != ChiSquaredCritical(2, 0.01) {
+		z.Errorf("KruskalWallisTest(shifted group) = %v, want rejection using the chi-squared approximation", r)
+	}
+}
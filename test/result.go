@@ -0,0 +1,97 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package test
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+)
+
+// Result is the outcome of a statistical test.
+//
+// The null hypothesis is rejected if the p-value is less than the
+// significance level alpha, or equivalently, if the statistic lies
+// beyond the critical value. Fields that do not apply to a test,
+// such as the degrees of freedom of the Kolmogorov-Smirnov test,
+// are NaN.
+//
+// The effect size describes how large a difference is, independently
+// of the number of values; each test documents which measure it uses.
+type Result struct {
+	Statistic float64
+	DF        float64 // degrees of freedom
+	PValue    float64
+	Critical  float64 // critical value of the statistic at Alpha
+	Alpha     float64
+	Reject    bool // whether the null hypothesis is rejected
+
+	EffectSize float64
+
+	// Warnings describe conditions under which the result may not be
+	// reliable, such as observations that were dropped or expected
+	// counts that are too small for an approximation to hold.
+	Warnings []string
+}
+
+// newResult returns a result for the statistic and p-value at the
+// significance level alpha, with the remaining fields set to NaN.
+func newResult(stat, p, alpha float64) Result {
+	if !(0 < alpha && alpha < 1) {
+		panic("alpha must be in (0, 1)")
+	}
+	return Result{
+		Statistic: stat,
+		DF:        math.NaN(),
+		PValue:    p,
+		Critical:  math.NaN(),
+		Alpha:     alpha,
+		Reject:    p < alpha,
+
+		EffectSize: math.NaN(),
+	}
+}
+
+func (r *Result) warnf(format string, args ...interface{}) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+func (r Result) String() string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "statistic=%g", r.Statistic)
+	if !math.IsNaN(r.DF) {
+		fmt.Fprintf(&buf, " df=%g", r.DF)
+	}
+	fmt.Fprintf(&buf, " p=%g", r.PValue)
+	if !math.IsNaN(r.Critical) {
+		fmt.Fprintf(&buf, " critical=%g", r.Critical)
+	}
+	if !math.IsNaN(r.EffectSize) {
+		fmt.Fprintf(&buf, " effect=%g", r.EffectSize)
+	}
+	if r.Reject {
+		fmt.Fprintf(&buf, " reject at α=%g", r.Alpha)
+	} else {
+		fmt.Fprintf(&buf, " accept at α=%g", r.Alpha)
+	}
+	for _, w := range r.Warnings {
+		fmt.Fprintf(&buf, "; warning: %s", w)
+	}
+	return buf.String()
+}
+
+// critical returns the smallest statistic in [lo, hi] whose p-value,
+// as given by the decreasing function p, is at most alpha.
+func critical(p func(float64) float64, alpha, lo, hi float64) float64 {
+	for i := 0; i < 100 && hi-lo > 1e-12*math.Max(1, math.Abs(hi)); i++ {
+		mid := lo + (hi-lo)/2
+		if p(mid) <= alpha {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return hi
+}
@@ -3,91 +3,233 @@
 // that can be found in the LICENSE file.
 
 // Package test provides statistical tests, such as the ChiSquaredTest.
-//
-// This package may be removed, as the main function, ChiSquaredTest,
-// is only an approximation.
 package test
 
 import (
-	"fmt"
 	"math"
-	"os"
 
 	"github.com/goulash/stat"
 	"github.com/goulash/stat/dist"
-	"github.com/goulash/stat/statutil"
 )
 
 // ChiSquaredTest performs a statistical test on the given series.
-func ChiSquaredTest(s stat.Series, d dist.Dist, k int, alpha float64) (ok bool, r float64) {
-	n := len(s)
-	if s == nil || d == nil {
+//
+// The series s is sorted into k bins that are equally probable under d,
+// and the chi-squared statistic is compared with the critical value of
+// the chi-squared distribution with k-1 degrees of freedom at the
+// significance level alpha. If the result does not reject, the hypothesis
+// that s comes from d cannot be rejected.
+//
+// Values that do not land in any bin are dropped with a warning, and a
+// warning is given if the expected counts are too small. For more control
+// over the bins, see ChiSquaredTestBins.
+func ChiSquaredTest(s stat.Series, d dist.Dist, k int, alpha float64) Result {
+	return ChiSquaredTestBins(s, d, Binning{K: k}, alpha)
+}
+
+// Binning describes how ChiSquaredTestBins sorts values into bins.
+type Binning struct {
+	// Edges are the edges of the bins in increasing order, where bin i
+	// contains the values in [Edges[i], Edges[i+1]). If Edges is nil,
+	// K bins are used that are equally probable under the distribution.
+	// For discrete distributions, the quantiles that bound these bins can
+	// coincide, so there may be fewer bins, of unequal probability.
+	Edges []float64
+	K     int
+
+	// OpenEnded extends the first bin to -∞ and the last bin to +∞,
+	// so that no values are dropped.
+	OpenEnded bool
+
+	// MinExpected is the smallest expected count of a bin. Adjacent bins
+	// are merged from left to right until each reaches it; a remainder at
+	// the end is merged into the last bin. If zero, no bins are merged.
+	MinExpected float64
+
+	// Estimated is the number of parameters of the distribution that were
+	// estimated from the values, such as by the Fit functions of package
+	// dist. Each reduces the degrees of freedom by one.
+	Estimated int
+}
+
+// edges returns the edges of the bins for the distribution d, and the
+// probability of each bin.
+func (b Binning) edges(d dist.DistP) (edges, ps []float64) {
+	if b.Edges != nil {
+		if len(b.Edges) < 2 {
+			panic("at least two edges are required")
+		}
+		for i := 1; i < len(b.Edges); i++ {
+			if !(b.Edges[i-1] < b.Edges[i]) {
+				panic("edges must be strictly increasing")
+			}
+		}
+		edges = append([]float64(nil), b.Edges...)
+	} else {
+		q, ok := d.(dist.Dist)
+		if !ok {
+			panic("distribution has no quantile function, so edges are required")
+		}
+		if b.K < 2 {
+			panic("at least two bins are required")
+		}
+		for i := 0; i <= b.K; i++ {
+			x := q.Q(float64(i) / float64(b.K))
+			if len(edges) == 0 || x > edges[len(edges)-1] {
+				edges = append(edges, x)
+			}
+		}
+
+		// The largest value Q(1) belongs in the last bin, which matters
+		// if it has a positive probability, as in discrete distributions.
+		if hi := edges[len(edges)-1]; !math.IsInf(hi, 1) {
+			if len(edges) == 1 {
+				edges = append(edges, hi)
+			}
+			edges[len(edges)-1] = math.Nextafter(hi, math.Inf(1))
+		}
+	}
+
+	if b.OpenEnded {
+		edges[0] = math.Inf(-1)
+		edges[len(edges)-1] = math.Inf(1)
+	}
+
+	// As the bins are [lo, hi), the probability of a bin is that of a
+	// value less than hi but not less than lo. For distributions with
+	// atoms, such as discrete distributions, this differs from
+	// P(hi) - P(lo), which covers (lo, hi].
+	ps = make([]float64, len(edges)-1)
+	for i := range ps {
+		ps[i] = d.P(below(edges[i+1])) - d.P(below(edges[i]))
+	}
+	return edges, ps
+}
+
+// below returns the largest float64 less than x.
+func below(x float64) float64 {
+	return math.Nextafter(x, math.Inf(-1))
+}
+
+// ChiSquaredTestBins performs the chi-squared goodness-of-fit test of the
+// hypothesis that the series s comes from the distribution d, with the
+// bins described by b.
+//
+// The values of s that land in a bin are counted, and compared with the
+// counts expected under d, given that the values lie within the bins.
+// Values outside the bins are dropped with a warning. The statistic has
+// k-1-b.Estimated degrees of freedom, where k is the number of bins after
+// merging. If this is less than one, the p-value is NaN and a warning is
+// given. A warning is also given if the expected counts are too small,
+// see ContingencyTable.ChiSquaredTest.
+func ChiSquaredTestBins(s stat.Series, d dist.DistP, b Binning, alpha float64) Result {
+	if s == nil || d == nil || b.Estimated < 0 {
 		panic("invalid values submitted")
 	}
 
-	// Make a histogram and get values please. We use the distribution inverse for this.
-	ks := make([]float64, k+1)
-	for i := range ks {
-		ks[i] = d.Q(float64(i) / float64(k))
+	edges, ps := b.edges(d)
+	counts := Bins(edges, s)
+	var n int
+	var total float64
+	for i, c := range counts {
+		n += c
+		total += ps[i]
+	}
+
+	// Merge adjacent bins whose expected counts are too small.
+	var os, es []float64
+	var o, e float64
+	for i, c := range counts {
+		o += float64(c)
+		e += float64(n) * ps[i] / total
+		if e >= b.MinExpected {
+			os, es = append(os, o), append(es, e)
+			o, e = 0, 0
+		}
+	}
+	if e > 0 || o > 0 {
+		if len(es) == 0 {
+			os, es = append(os, 0), append(es, 0)
+		}
+		os[len(os)-1] += o
+		es[len(es)-1] += e
 	}
-	bs := Bins(ks, s)
-	exp := float64(n) / float64(k)
 
-	sum := 0
 	var chi2 float64
-	for _, b := range bs {
-		sum += b
-		chi2 += math.Pow(float64(b)-exp, 2) / exp
+	for i := range os {
+		if diff := os[i] - es[i]; diff != 0 {
+			chi2 += diff * diff / es[i]
+		}
 	}
-	if sum != n {
-		fmt.Fprintf(os.Stderr, "Error: expected sum to equal %d, got %d.\n", n, sum)
+
+	df := len(os) - 1 - b.Estimated
+	var r Result
+	if df < 1 {
+		r = newResult(chi2, math.NaN(), alpha)
+		r.warnf("%d bins leave no degrees of freedom for %d estimated parameters", len(os), b.Estimated)
+	} else {
+		r = newResult(chi2, ChiSquaredPValue(df, chi2), alpha)
+		r.DF = float64(df)
+		r.Critical = ChiSquaredCritical(df, alpha)
+	}
+	if dropped := len(s) - n; dropped > 0 {
+		r.warnf("%d of %d values lie outside the bins", dropped, len(s))
 	}
-	return chi2 < ChiSquared.Value(float64(k-1), alpha), chi2
+	warnExpected(&r, [][]float64{es})
+	return r
 }
 
-var ChiSquared = statutil.NewApproxTable(
-	[]float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 40, 50, 60, 70, 80, 90, 100},
-	[]float64{0.995, 0.99, 0.975, 0.95, 0.90, 0.10, 0.05, 0.025, 0.01, 0.005},
-	[][]float64{
-		[]float64{0.000, 0.000, 0.001, 0.004, 0.016, 2.706, 3.841, 5.024, 6.635, 7.879},
-		[]float64{0.010, 0.020, 0.051, 0.103, 0.211, 4.605, 5.991, 7.378, 9.210, 10.597},
-		[]float64{0.072, 0.115, 0.216, 0.352, 0.584, 6.251, 7.815, 9.348, 11.345, 12.838},
-		[]float64{0.207, 0.297, 0.484, 0.711, 1.064, 7.779, 9.488, 11.143, 13.277, 14.860},
-		[]float64{0.412, 0.554, 0.831, 1.145, 1.610, 9.236, 11.070, 12.833, 15.086, 16.750},
-		[]float64{0.676, 0.872, 1.237, 1.635, 2.204, 10.645, 12.592, 14.449, 16.812, 18.548},
-		[]float64{0.989, 1.239, 1.690, 2.167, 2.833, 12.017, 14.067, 16.013, 18.475, 20.278},
-		[]float64{1.344, 1.646, 2.180, 2.733, 3.490, 13.362, 15.507, 17.535, 20.090, 21.955},
-		[]float64{1.735, 2.088, 2.700, 3.325, 4.168, 14.684, 16.919, 19.023, 21.666, 23.589},
-		[]float64{2.156, 2.558, 3.247, 3.940, 4.865, 15.987, 18.307, 20.483, 23.209, 25.188},
-		[]float64{2.603, 3.053, 3.816, 4.575, 5.578, 17.275, 19.675, 21.920, 24.725, 26.757},
-		[]float64{3.074, 3.571, 4.404, 5.226, 6.304, 18.549, 21.026, 23.337, 26.217, 28.300},
-		[]float64{3.565, 4.107, 5.009, 5.892, 7.042, 19.812, 22.362, 24.736, 27.688, 29.819},
-		[]float64{4.075, 4.660, 5.629, 6.571, 7.790, 21.064, 23.685, 26.119, 29.141, 31.319},
-		[]float64{4.601, 5.229, 6.262, 7.261, 8.547, 22.307, 24.996, 27.488, 30.578, 32.801},
-		[]float64{5.142, 5.812, 6.908, 7.962, 9.312, 23.542, 26.296, 28.845, 32.000, 34.267},
-		[]float64{5.697, 6.408, 7.564, 8.672, 10.085, 24.769, 27.587, 30.191, 33.409, 35.718},
-		[]float64{6.265, 7.015, 8.231, 9.390, 10.865, 25.989, 28.869, 31.526, 34.805, 37.156},
-		[]float64{6.844, 7.633, 8.907, 10.117, 11.651, 27.204, 30.144, 32.852, 36.191, 38.582},
-		[]float64{7.434, 8.260, 9.591, 10.851, 12.443, 28.412, 31.410, 34.170, 37.566, 39.997},
-		[]float64{8.034, 8.897, 10.283, 11.591, 13.240, 29.615, 32.671, 35.479, 38.932, 41.401},
-		[]float64{8.643, 9.542, 10.982, 12.338, 14.041, 30.813, 33.924, 36.781, 40.289, 42.796},
-		[]float64{9.260, 10.196, 11.689, 13.091, 14.848, 32.007, 35.172, 38.076, 41.638, 44.181},
-		[]float64{9.886, 10.856, 12.401, 13.848, 15.659, 33.196, 36.415, 39.364, 42.980, 45.559},
-		[]float64{10.520, 11.524, 13.120, 14.611, 16.473, 34.382, 37.652, 40.646, 44.314, 46.928},
-		[]float64{11.160, 12.198, 13.844, 15.379, 17.292, 35.563, 38.885, 41.923, 45.642, 48.290},
-		[]float64{11.808, 12.879, 14.573, 16.151, 18.114, 36.741, 40.113, 43.195, 46.963, 49.645},
-		[]float64{12.461, 13.565, 15.308, 16.928, 18.939, 37.916, 41.337, 44.461, 48.278, 50.993},
-		[]float64{13.121, 14.256, 16.047, 17.708, 19.768, 39.087, 42.557, 45.722, 49.588, 52.336},
-		[]float64{13.787, 14.953, 16.791, 18.493, 20.599, 40.256, 43.773, 46.979, 50.892, 53.672},
-		[]float64{20.707, 22.164, 24.433, 26.509, 29.051, 51.805, 55.758, 59.342, 63.691, 66.766},
-		[]float64{27.991, 29.707, 32.357, 34.764, 37.689, 63.167, 67.505, 71.420, 76.154, 79.490},
-		[]float64{35.534, 37.485, 40.482, 43.188, 46.459, 74.397, 79.082, 83.298, 88.379, 91.952},
-		[]float64{43.275, 45.442, 48.758, 51.739, 55.329, 85.527, 90.531, 95.023, 100.425, 104.215},
-		[]float64{51.172, 53.540, 57.153, 60.391, 64.278, 96.578, 101.879, 106.629, 112.329, 116.321},
-		[]float64{59.196, 61.754, 65.647, 69.126, 73.291, 107.565, 113.145, 118.136, 124.116, 128.299},
-		[]float64{67.328, 70.065, 74.222, 77.929, 82.358, 118.498, 124.342, 129.561, 135.807, 140.169},
-	},
-)
+// ChiSquaredGoodnessOfFit performs Pearson's chi-squared test of the
+// hypothesis that the observed counts come from a categorical distribution
+// with the given probabilities. The probabilities are normalized, so they
+// may also be given as relative weights.
+//
+// The statistic Σ(O-E)²/E is compared with the chi-squared distribution
+// with k-1 degrees of freedom, where k is the number of categories.
+// A warning is given if the expected counts are too small for this
+// approximation, see ContingencyTable.ChiSquaredTest.
+func ChiSquaredGoodnessOfFit(observed []int, probs []float64, alpha float64) Result {
+	k := len(observed)
+	if k < 2 || len(probs) != k {
+		panic("there must be a probability for each of at least two counts")
+	}
+	var n int
+	var sum float64
+	for i, o := range observed {
+		if o < 0 || !(probs[i] > 0) {
+			panic("counts must not be negative and probabilities must be positive")
+		}
+		n += o
+		sum += probs[i]
+	}
+
+	es := make([]float64, k)
+	var chi2 float64
+	for i, o := range observed {
+		es[i] = float64(n) * probs[i] / sum
+		d := float64(o) - es[i]
+		chi2 += d * d / es[i]
+	}
+
+	r := newResult(chi2, ChiSquaredPValue(k-1, chi2), alpha)
+	r.DF = float64(k - 1)
+	r.Critical = ChiSquaredCritical(k-1, alpha)
+	warnExpected(&r, [][]float64{es})
+	return r
+}
+
+// ChiSquaredCritical returns the critical value of the chi-squared
+// distribution with k degrees of freedom for the significance level alpha,
+// that is, the value that is exceeded with probability alpha.
+func ChiSquaredCritical(k int, alpha float64) float64 {
+	return dist.NewChiSquared(nil, float64(k)).Q(1 - alpha)
+}
+
+// ChiSquaredPValue returns the probability that a chi-squared distributed
+// value with k degrees of freedom is at least chi2.
+func ChiSquaredPValue(k int, chi2 float64) float64 {
+	return dist.NewChiSquared(nil, float64(k)).Survival(chi2)
+}
 
 // Bins returns the number of values of s that land in the len(ks)-1 bins.
 func Bins(ks []float64, s stat.Series) []int {
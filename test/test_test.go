@@ -0,0 +1,150 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package test
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/goulash/stat"
+	"github.com/goulash/stat/dist"
+)
+
+func TestChiSquaredTest(z *testing.T) {
+	src := rand.NewSource(4)
+	xs := sample(dist.NewNormal(src, 0, 1), 1000)
+
+	r := ChiSquaredTest(xs, dist.NewNormal(src, 0, 1), 10, 0.01)
+	if r.Reject || r.DF != 9 || len(r.Warnings) != 0 {
+		z.Errorf("ChiSquaredTest(normal) = %v, want no rejection with 9 degrees of freedom", r)
+	}
+	if want := ChiSquaredCritical(9, 0.01); r.Critical != want {
+		z.Errorf("ChiSquaredTest(normal): critical value %v, want %v", r.Critical, want)
+	}
+	if want := ChiSquaredPValue(9, r.Statistic); math.Abs(r.PValue-want) > 1e-15 {
+		z.Errorf("ChiSquaredTest(normal): p-value %v, want %v", r.PValue, want)
+	}
+
+	r = ChiSquaredTest(xs, dist.NewNormal(src, 0.3, 1), 10, 0.01)
+	if !r.Reject || r.Statistic <= r.Critical {
+		z.Errorf("ChiSquaredTest(shifted normal) = %v, want rejection", r)
+	}
+
+	// Values outside the support are dropped with a warning, and small
+	// expected counts also give a warning.
+	r = ChiSquaredTest(stat.Series{0.1, 0.2, 0.6, 0.7, 2}, dist.NewUniform(src, 0, 1), 2, 0.05)
+	if len(r.Warnings) != 2 || !strings.Contains(r.Warnings[0], "1 of 5") {
+		z.Errorf("ChiSquaredTest: warnings %q, want two warnings", r.Warnings)
+	}
+	if !strings.Contains(r.String(), "warning") {
+		z.Errorf("Result.String() = %q, want warnings to be included", r.String())
+	}
+}
+
+func TestChiSquaredTestPValue(z *testing.T) {
+	// Five bins of equal probability with 20 expected values each. With
+	// four degrees of freedom, the p-value is exp(-x/2) (1 + x/2).
+	tests := []struct {
+		Counts [5]int
+		Chi2   float64
+		P      float64
+	}{
+		{[5]int{25, 20, 20, 20, 15}, 2.5, 0.6446357929354277},
+		{[5]int{60, 10, 10, 10, 10}, 100, 9.83662422461598e-21},
+	}
+	for _, t := range tests {
+		var xs stat.Series
+		for i, n := range t.Counts {
+			for j := 0; j < n; j++ {
+				xs = append(xs, 0.1+0.2*float64(i))
+			}
+		}
+		r := ChiSquaredTest(xs, dist.NewUniform(rand.NewSource(1), 0, 1), 5, 0.05)
+		if math.Abs(r.Statistic-t.Chi2) > 1e-12 || math.Abs(r.PValue-t.P) > 1e-12*t.P {
+			z.Errorf("ChiSquaredTest(%v) = %v, want statistic %v and p-value %v", t.Counts, r, t.Chi2, t.P)
+		}
+	}
+}
+
+// poissonSample returns n values from the Poisson distribution with mean lambda.
+func poissonSample(src rand.Source, lambda float64, n int) stat.Series {
+	p := dist.NewPoisson(src, lambda)
+	xs := make(stat.Series, n)
+	for i := range xs {
+		xs[i] = float64(p.Int63())
+	}
+	return xs
+}
+
+func TestChiSquaredTestBins(z *testing.T) {
+	src := rand.NewSource(8)
+	xs := sample(dist.NewExponential(src, 1), 100)
+
+	// Bins of width one, with those in the tail merged until the expected
+	// count is at least 5. The tail [3,∞) falls just short with 4.98, so
+	// it is merged into the bin before it: [0,1), [1,2), and [2,∞).
+	edges := []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	b := Binning{Edges: edges, OpenEnded: true, MinExpected: 5, Estimated: 1}
+	r := ChiSquaredTestBins(xs, dist.NewExponential(src, 1), b, 0.01)
+	if r.DF != 1 || len(r.Warnings) != 0 || r.Reject {
+		z.Errorf("ChiSquaredTestBins(exponential, merged) = %v, want 1 degree of freedom and no warnings", r)
+	}
+
+	// Without merging, the expected counts in the tail are too small.
+	b.MinExpected = 0
+	if r := ChiSquaredTestBins(xs, dist.NewExponential(src, 1), b, 0.01); r.DF != 8 || len(r.Warnings) != 1 {
+		z.Errorf("ChiSquaredTestBins(exponential) = %v, want 8 degrees of freedom and a warning", r)
+	}
+
+	// Closed bins drop the values outside them.
+	ys := sample(dist.NewNormal(src, 0, 1), 200)
+	r = ChiSquaredTestBins(ys, dist.NewNormal(src, 0, 1), Binning{Edges: []float64{-1, 0, 1}}, 0.05)
+	if r.DF != 1 || len(r.Warnings) != 1 || !strings.Contains(r.Warnings[0], "outside") {
+		z.Errorf("ChiSquaredTestBins(normal, closed) = %v, want a warning about dropped values", r)
+	}
+	r = ChiSquaredTestBins(ys, dist.NewNormal(src, 0, 1), Binning{Edges: []float64{-1, 0, 1}, OpenEnded: true}, 0.05)
+	if r.DF != 1 || len(r.Warnings) != 0 || r.Reject {
+		z.Errorf("ChiSquaredTestBins(normal, open) = %v, want no warnings", r)
+	}
+
+	// Equiprobable open-ended bins also work when the quantiles at 0 and 1
+	// are infinite.
+	r = ChiSquaredTestBins(ys, dist.NewNormal(src, 0, 1), Binning{K: 8, OpenEnded: true, Estimated: 2}, 0.05)
+	if r.DF != 5 || len(r.Warnings) != 0 || r.Reject {
+		z.Errorf("ChiSquaredTestBins(normal, equiprobable) = %v, want 5 degrees of freedom", r)
+	}
+
+	// Discrete distributions count the values at an edge in the bin that
+	// starts there, with the same share as expected.
+	ks := poissonSample(rand.NewSource(3), 3, 5000)
+	edges = []float64{0, 1, 2, 3, 4, 5, 6, 7, 8}
+	r = ChiSquaredTestBins(ks, dist.AsDist(dist.NewPoisson(src, 3)), Binning{Edges: edges, OpenEnded: true}, 0.01)
+	if r.DF != 7 || len(r.Warnings) != 0 || r.Reject {
+		z.Errorf("ChiSquaredTestBins(poisson, integer edges) = %v, want no rejection", r)
+	}
+
+	// Equiprobable bins of discrete distributions merge coinciding quantiles.
+	r = ChiSquaredTest(ks, dist.AsDist(dist.NewPoisson(src, 3)), 10, 0.01)
+	if r.DF < 4 || r.Reject {
+		z.Errorf("ChiSquaredTest(poisson) = %v, want no rejection", r)
+	}
+	bin := dist.NewBinomial(rand.NewSource(5), 20, 0.4)
+	bs := make(stat.Series, 2000)
+	for i := range bs {
+		bs[i] = float64(bin.Int63())
+	}
+	r = ChiSquaredTest(bs, dist.AsDist(bin), 10, 0.01)
+	if r.DF < 4 || r.Reject || len(r.Warnings) != 0 {
+		z.Errorf("ChiSquaredTest(binomial) = %v, want no rejection", r)
+	}
+
+	// Estimating too many parameters leaves no degrees of freedom.
+	r = ChiSquaredTestBins(ys, dist.NewNormal(src, 0, 1), Binning{K: 3, Estimated: 2}, 0.05)
+	if !math.IsNaN(r.PValue) || r.Reject || len(r.Warnings) != 1 {
+		z.Errorf("ChiSquaredTestBins with no degrees of freedom = %v, want NaN p-value and a warning", r)
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package test
+
+import (
+	"math"
+
+	"github.com/goulash/stat"
+	"github.com/goulash/stat/dist"
+)
+
+// Alternative is the alternative hypothesis of a test that compares
+// a location, such as a mean, with another one.
+type Alternative int
+
+const (
+	TwoSided Alternative = iota // the first location differs from the second
+	Less                        // the first location is less than the second
+	Greater                     // the first location is greater than the second
+)
+
+// pvalue returns the p-value of the statistic x under the alternative a,
+// where cdf is the distribution function of the statistic and sf is its
+// survival function, which keeps the precision of small upper tails.
+func (a Alternative) pvalue(x float64, cdf, sf func(float64) float64) float64 {
+	switch a {
+	case TwoSided:
+		return math.Min(1, 2*math.Min(cdf(x), sf(x)))
+	case Less:
+		return cdf(x)
+	case Greater:
+		return sf(x)
+	default:
+		panic("unknown alternative")
+	}
+}
+
+// critical returns the critical value at alpha under the alternative a,
+// where q is the quantile function of a symmetric statistic. For TwoSided
+// the statistic is compared in absolute value.
+func (a Alternative) critical(alpha float64, q func(float64) float64) float64 {
+	switch a {
+	case TwoSided:
+		return q(1 - alpha/2)
+	case Less:
+		return q(alpha)
+	default:
+		return q(1 - alpha)
+	}
+}
+
+// TTest performs the one-sample t-test of the hypothesis that the mean
+// of s is mu. The values are assumed to be independent and their mean
+// to be approximately normally distributed.
+//
+// The statistic is t = (mean-mu)/(std/√n) with n-1 degrees of freedom,
+// and the effect size is Cohen's d = (mean-mu)/std. If alt is Less or
+// Greater, the alternative is that the mean is less or greater than mu.
+// If the values have no variance, t is ±Inf or NaN and a warning is given.
+func TTest(s stat.Series, mu float64, alt Alternative, alpha float64) Result {
+	return tTest(float64(len(s)), s.Mean(), s.Std(), mu, alt, alpha)
+}
+
+// TTestRun is like TTest, but uses the summary of the values in r.
+// Weights are treated as frequency weights, as in Run.MeanCI, so the
+// number of values is the total weight.
+func TTestRun(r stat.Run, mu float64, alt Alternative, alpha float64) Result {
+	return tTest(r.Weight(), r.Mean(), r.Std(), mu, alt, alpha)
+}
+
+// PairedTTest performs the paired t-test of the hypothesis that the mean
+// difference between the pairs s[i] and t[i] is zero, which is the
+// one-sample t-test of the differences s[i]-t[i] against 0.
+//
+// The effect size is Cohen's d of the differences.
+func PairedTTest(s, t stat.Series, alt Alternative, alpha float64) Result {
+	if len(s) != len(t) {
+		panic("series must have the same length")
+	}
+	return TTest(s.Sub(t), 0, alt, alpha)
+}
+
+func tTest(n, mean, std, mu float64, alt Alternative, alpha float64) Result {
+	if n < 2 {
+		panic("at least two values are required")
+	}
+
+	t := (mean - mu) / (std / math.Sqrt(n))
+	if std == 0 {
+		return degenerateT(t, alt, alpha)
+	}
+	td := dist.NewStudentT(nil, n-1)
+	r := newResult(t, alt.pvalue(t, td.P, td.Survival), alpha)
+	r.DF = n - 1
+	r.Critical = alt.critical(alpha, td.Q)
+	r.EffectSize = (mean - mu) / std
+	return r
+}
+
+// PooledTTest performs Student's two-sample t-test of the hypothesis that
+// s and t have the same mean, assuming that they have the same variance.
+// If alt is Less or Greater, the alternative is that the mean of s is less
+// or greater than the mean of t.
+//
+// The statistic uses the pooled variance and has n+m-2 degrees of freedom.
+// The effect size is Cohen's d, the difference of the means divided by the
+// pooled standard deviation. If neither sample has any variance, t is ±Inf
+// or NaN and a warning is given. Unless the variances are known to be
+// equal, WelchTTest is preferable.
+func PooledTTest(s, t stat.Series, alt Alternative, alpha float64) Result {
+	return tTest2(float64(len(s)), s.Mean(), s.Var(), float64(len(t)), t.Mean(), t.Var(), false, alt, alpha)
+}
+
+// PooledTTestRun is like PooledTTest, but uses the summaries in a and b,
+// with weights treated as in TTestRun.
+func PooledTTestRun(a, b stat.Run, alt Alternative, alpha float64) Result {
+	return tTest2(a.Weight(), a.Mean(), a.Var(), b.Weight(), b.Mean(), b.Var(), false, alt, alpha)
+}
+
+// WelchTTest performs Welch's two-sample t-test of the hypothesis that
+// s and t have the same mean, without assuming that they have the same
+// variance. If alt is Less or Greater, the alternative is that the mean
+// of s is less or greater than the mean of t.
+//
+// The degrees of freedom are given by the Welch-Satterthwaite equation,
+// and are generally not an integer. The effect size and samples without
+// variance are treated as in PooledTTest.
+func WelchTTest(s, t stat.Series, alt Alternative, alpha float64) Result {
+	return tTest2(float64(len(s)), s.Mean(), s.Var(), float64(len(t)), t.Mean(), t.Var(), true, alt, alpha)
+}
+
+// WelchTTestRun is like WelchTTest, but uses the summaries in a and b,
+// with weights treated as in TTestRun.
+func WelchTTestRun(a, b stat.Run, alt Alternative, alpha float64) Result {
+	return tTest2(a.Weight(), a.Mean(), a.Var(), b.Weight(), b.Mean(), b.Var(), true, alt, alpha)
+}
+
+func tTest2(n1, m1, v1, n2, m2, v2 float64, welch bool, alt Alternative, alpha float64) Result {
+	if n1 < 2 || n2 < 2 {
+		panic("at least two values are required in each sample")
+	}
+
+	pooled := ((n1-1)*v1 + (n2-1)*v2) / (n1 + n2 - 2)
+	var se, df float64
+	if welch {
+		a, b := v1/n1, v2/n2
+		se = math.Sqrt(a + b)
+		df = (a + b) * (a + b) / (a*a/(n1-1) + b*b/(n2-1))
+	} else {
+		se = math.Sqrt(pooled * (1/n1 + 1/n2))
+		df = n1 + n2 - 2
+	}
+
+	t := (m1 - m2) / se
+	if se == 0 {
+		return degenerateT(t, alt, alpha)
+	}
+	td := dist.NewStudentT(nil, df)
+	r := newResult(t, alt.pvalue(t, td.P, td.Survival), alpha)
+	r.DF = df
+	r.Critical = alt.critical(alpha, td.Q)
+	r.EffectSize = (m1 - m2) / math.Sqrt(pooled)
+	return r
+}
+
+// degenerateT returns the result of a t-test whose values have no
+// variance, so that t is ±Inf if the means differ and NaN otherwise.
+// The p-value is then 0 or 1 for ±Inf, and NaN for NaN.
+func degenerateT(t float64, alt Alternative, alpha float64) Result {
+	step := func(x float64) float64 {
+		if x > 0 {
+			return 1
+		} else if x < 0 {
+			return 0
+		}
+		return math.NaN()
+	}
+	sf := func(x float64) float64 { return 1 - step(x) }
+	r := newResult(t, alt.pvalue(t, step, sf), alpha)
+	r.warnf("the values have no variance, so the statistic is %g", t)
+	return r
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/goulash/stat"
+	"github.com/goulash/stat/statutil"
+)
+
+// Student's sleep data: the extra hours of sleep of ten patients
+// under two different drugs.
+var (
+	sleep1 = stat.Series{0.7, -1.6, -0.2, -1.2, -0.1, 3.4, 3.7, 0.8, 0.0, 2.0}
+	sleep2 = stat.Series{1.9, 0.8, 1.1, 0.1, -0.1, 4.4, 5.5, 1.6, 4.6, 3.4}
+)
+
+func run(s stat.Series) stat.Run {
+	var r stat.Run
+	for _, x := range s {
+		r.Add(x)
+	}
+	return r
+}
+
+func TestTTest(z *testing.T) {
+	tests := []struct {
+		name string
+		r    Result
+		t    float64
+		df   float64
+		p    float64
+		d    float64
+	}{
+		{"welch", WelchTTest(sleep1, sleep2, TwoSided, 0.05), -1.8608134674868526, 17.776473516178495, 0.07939414018705333, -0.8321810813495395},
+		{"welch run", WelchTTestRun(run(sleep1), run(sleep2), TwoSided, 0.05), -1.8608134674868526, 17.776473516178495, 0.07939414018705333, -0.8321810813495395},
+		{"welch less", WelchTTest(sleep1, sleep2, Less, 0.05), -1.8608134674868526, 17.776473516178495, 0.07939414018705333 / 2, -0.8321810813495395},
+		{"welch greater", WelchTTest(sleep1, sleep2, Greater, 0.05), -1.8608134674868526, 17.776473516178495, 1 - 0.07939414018705333/2, -0.8321810813495395},
+		{"pooled", PooledTTest(sleep1, sleep2, TwoSided, 0.05), -1.8608134674868526, 18, 0.07918671421563322, -0.8321810813495395},
+		{"pooled run", PooledTTestRun(run(sleep1), run(sleep2), TwoSided, 0.05), -1.8608134674868526, 18, 0.07918671421563322, -0.8321810813495395},
+		{"paired", PairedTTest(sleep1, sleep2, TwoSided, 0.05), -4.062127683382037, 9, 0.002832890197303884, -1.2845575625910546},
+		{"one-sample", TTest(sleep1.Sub(sleep2), 0, TwoSided, 0.05), -4.062127683382037, 9, 0.002832890197303884, -1.2845575625910546},
+		{"one-sample run", TTestRun(run(sleep1.Sub(sleep2)), 0, TwoSided, 0.05), -4.062127683382037, 9, 0.002832890197303884, -1.2845575625910546},
+	}
+
+	near := func(a, b float64) bool { return math.Abs(a-b) <= 1e-8*math.Max(1, math.Abs(b)) }
+	for _, t := range tests {
+		r := t.r
+		if !near(r.Statistic, t.t) || !near(r.DF, t.df) || !near(r.PValue, t.p) || !near(r.EffectSize, t.d) {
+			z.Errorf("%s t-test = %v, want t=%v df=%v p=%v d=%v", t.name, r, t.t, t.df, t.p, t.d)
+		}
+		if r.Reject != (t.p < 0.05) {
+			z.Errorf("%s t-test: reject = %v, want %v", t.name, r.Reject, t.p < 0.05)
+		}
+	}
+
+	// A large t keeps a positive p-value. With two degrees of freedom, the
+	// two-sided p-value is 2/(√(t²+2)(√(t²+2)+|t|)).
+	for _, alt := range []Alternative{TwoSided, Greater} {
+		r := TTest(stat.Series{1e9 - 1, 1e9, 1e9 + 1}, 0, alt, 0.05)
+		s := math.Sqrt(r.Statistic*r.Statistic + 2)
+		want := 1 / (s * (s + r.Statistic))
+		if alt == TwoSided {
+			want *= 2
+		}
+		if math.Abs(r.PValue-want) > 1e-9*want {
+			z.Errorf("t-test with t=%v: p-value %v, want %v", r.Statistic, r.PValue, want)
+		}
+	}
+
+	// The critical values are the quantiles of the t distribution, and the
+	// decision agrees with the comparison of statistic and critical value.
+	r := PairedTTest(sleep1, sleep2, TwoSided, 0.01)
+	if want := statutil.StudentTQuantile(0.995, 9); !near(r.Critical, want) {
+		z.Errorf("paired t-test: critical value %v, want %v", r.Critical, want)
+	}
+	if r.Reject != (math.Abs(r.Statistic) > r.Critical) {
+		z.Errorf("paired t-test = %v: decision disagrees with critical value", r)
+	}
+	r = TTest(sleep2, 0, Greater, 0.05)
+	if want := statutil.StudentTQuantile(0.95, 9); !near(r.Critical, want) || !r.Reject {
+		z.Errorf("one-sided t-test = %v, want critical value %v and rejection", r, want)
+	}
+	r = TTest(sleep2, 0, Less, 0.05)
+	if want := statutil.StudentTQuantile(0.05, 9); !near(r.Critical, want) || r.Reject {
+		z.Errorf("one-sided t-test = %v, want critical value %v and no rejection", r, want)
+	}
+
+	// Weights are frequencies, so a weight of 2 is the same as adding
+	// each value twice.
+	weighted := func(s stat.Series) stat.Run {
+		var r stat.Run
+		for _, x := range s {
+			r.AddWeighted(x, 2)
+		}
+		return r
+	}
+	twice := func(s stat.Series) stat.Series { return append(s.Copy(), s...) }
+	for _, t := range []struct {
+		name string
+		r, w Result
+	}{
+		{"welch", WelchTTest(twice(sleep1), twice(sleep2), TwoSided, 0.05), WelchTTestRun(weighted(sleep1), weighted(sleep2), TwoSided, 0.05)},
+		{"pooled", PooledTTest(twice(sleep1), twice(sleep2), TwoSided, 0.05), PooledTTestRun(weighted(sleep1), weighted(sleep2), TwoSided, 0.05)},
+		{"one-sample", TTest(twice(sleep1), 0, TwoSided, 0.05), TTestRun(weighted(sleep1), 0, TwoSided, 0.05)},
+	} {
+		if !near(t.w.Statistic, t.r.Statistic) || !near(t.w.DF, t.r.DF) || !near(t.w.PValue, t.r.PValue) {
+			z.Errorf("weighted %s t-test = %v, want %v", t.name, t.w, t.r)
+		}
+	}
+}
+
+func TestTTestNoVariance(z *testing.T) {
+	ones, twos := stat.Series{1, 1, 1}, stat.Series{2, 2, 2}
+	tests := []struct {
+		name string
+		r    Result
+		t, p float64
+	}{
+		{"welch equal", WelchTTest(ones, ones, TwoSided, 0.05), math.NaN(), math.NaN()},
+		{"pooled equal", PooledTTest(ones, ones, TwoSided, 0.05), math.NaN(), math.NaN()},
+		{"one-sample equal", TTest(ones, 1, TwoSided, 0.05), math.NaN(), math.NaN()},
+		{"welch", WelchTTest(ones, twos, TwoSided, 0.05), math.Inf(-1), 0},
+		{"welch less", WelchTTest(ones, twos, Less, 0.05), math.Inf(-1), 0},
+		{"welch greater", WelchTTest(ones, twos, Greater, 0.05), math.Inf(-1), 1},
+		{"pooled", PooledTTest(twos, ones, TwoSided, 0.05), math.Inf(1), 0},
+		{"one-sample", TTest(twos, 1, Greater, 0.05), math.Inf(1), 0},
+	}
+	same := func(a, b float64) bool { return a == b || math.IsNaN(a) && math.IsNaN(b) }
+	for _, t := range tests {
+		r := t.r
+		if !same(r.Statistic, t.t) || !same(r.PValue, t.p) || len(r.Warnings) != 1 {
+			z.Errorf("%s t-test = %v, want t=%v p=%v and a warning", t.name, r, t.t, t.p)
+		}
+		if r.Reject != (t.p < 0.05) {
+			z.Errorf("%s t-test: reject = %v, want %v", t.name, r.Reject, t.p < 0.05)
+		}
+	}
+}
@@ -0,0 +1,289 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package stat
+
+import "math"
+
+// welford keeps a running mean and sum of squared deviations that
+// values can be both added to and removed from.
+type welford struct {
+	n int
+	m float64
+	s float64
+}
+
+func (w *welford) add(x float64) {
+	w.n++
+	d := x - w.m
+	w.m += d / float64(w.n)
+	w.s += d * (x - w.m)
+}
+
+func (w *welford) remove(x float64) {
+	if w.n <= 1 {
+		*w = welford{}
+		return
+	}
+	w.n--
+	d := x - w.m
+	w.m -= d / float64(w.n)
+	w.s -= d * (x - w.m)
+	if w.s < 0 {
+		// Rounding errors should never make the variance negative.
+		w.s = 0
+	}
+}
+
+func (w *welford) mean() float64 {
+	if w.n == 0 {
+		return math.NaN()
+	}
+	return w.m
+}
+
+func (w *welford) variance(sample bool) float64 {
+	if w.n <= 1 {
+		if w.n == 0 {
+			return math.NaN()
+		}
+		return 0
+	}
+	if sample {
+		return w.s / float64(w.n-1)
+	}
+	return w.s / float64(w.n)
+}
+
+// MovingWindow calculates the mean, variance, and standard deviation
+// of the last n values added, in O(1) time per value.
+//
+// Min and Max scan the window, and therefore take O(n) time.
+//
+// A MovingWindow must be created with NewMovingWindow, as the zero value
+// has no size; Add panics if it is used.
+type MovingWindow struct {
+	z  welford
+	xs []float64
+	i  int // index of the oldest value once the window is full
+}
+
+// NewMovingWindow returns a window over the last n values.
+func NewMovingWindow(n int) *MovingWindow {
+	if n <= 0 {
+		panic("window size must be positive")
+	}
+	return &MovingWindow{xs: make([]float64, 0, n)}
+}
+
+// Reset removes all values, but keeps the window size.
+func (w *MovingWindow) Reset() {
+	w.z = welford{}
+	w.xs = w.xs[:0]
+	w.i = 0
+}
+
+// Add a new value to the window, removing the oldest value if the
+// window is full.
+func (w *MovingWindow) Add(x float64) {
+	if cap(w.xs) == 0 {
+		panic("MovingWindow must be created with NewMovingWindow")
+	} else if len(w.xs) < cap(w.xs) {
+		w.xs = append(w.xs, x)
+		w.z.add(x)
+		return
+	}
+
+	w.z.remove(w.xs[w.i])
+	w.z.add(x)
+	w.xs[w.i] = x
+	w.i = (w.i + 1) % len(w.xs)
+}
+
+// Series returns the values in the window, from oldest to newest.
+func (w *MovingWindow) Series() Series {
+	s := make(Series, 0, len(w.xs))
+	s = append(s, w.xs[w.i:]...)
+	return append(s, w.xs[:w.i]...)
+}
+
+func (w *MovingWindow) N() int64      { return int64(w.z.n) }
+func (w *MovingWindow) Max() float64  { return Max(w.xs) }
+func (w *MovingWindow) Min() float64  { return Min(w.xs) }
+func (w *MovingWindow) Mean() float64 { return w.z.mean() }
+func (w *MovingWindow) Var() float64  { return w.z.variance(true) }
+func (w *MovingWindow) VarP() float64 { return w.z.variance(false) }
+func (w *MovingWindow) Std() float64  { return math.Sqrt(w.Var()) }
+func (w *MovingWindow) StdP() float64 { return math.Sqrt(w.VarP()) }
+
+// TimeWindow calculates the mean, variance, and standard deviation of
+// the values added within the last d units of time.
+//
+// Values must be added in chronological order. A value added at time t
+// is removed once a value is added at time t+d or later.
+//
+// Min and Max scan the window, and therefore take O(n) time.
+//
+// A TimeWindow must be created with NewTimeWindow, as the zero value
+// has no duration; Add panics if it is used.
+type TimeWindow struct {
+	d  Time
+	z  welford
+	ts []Time
+	xs Series
+}
+
+// NewTimeWindow returns a window over the last d units of time.
+func NewTimeWindow(d Time) *TimeWindow {
+	if d == 0 {
+		panic("window duration must be positive")
+	}
+	return &TimeWindow{d: d}
+}
+
+// Reset removes all values, but keeps the window duration.
+func (w *TimeWindow) Reset() {
+	*w = TimeWindow{d: w.d}
+}
+
+// Add a new value to the window at time t, removing the values that
+// have fallen out of the window.
+func (w *TimeWindow) Add(t Time, x float64) {
+	if w.d == 0 {
+		panic("TimeWindow must be created with NewTimeWindow")
+	} else if n := len(w.ts); n > 0 && t < w.ts[n-1] {
+		panic("values must be added in chronological order")
+	}
+
+	var i int
+	for i < len(w.ts) && w.ts[i]+w.d <= t {
+		w.z.remove(w.xs[i])
+		i++
+	}
+	if i > 0 {
+		// Shift the remaining values to the front, so that the
+		// underlying arrays do not grow without bound.
+		n := copy(w.ts, w.ts[i:])
+		copy(w.xs, w.xs[i:])
+		w.ts, w.xs = w.ts[:n], w.xs[:n]
+	}
+
+	w.ts = append(w.ts, t)
+	w.xs = append(w.xs, x)
+	w.z.add(x)
+}
+
+// Times returns the times of the values in the window.
+func (w *TimeWindow) Times() []Time {
+	ts := make([]Time, len(w.ts))
+	copy(ts, w.ts)
+	return ts
+}
+
+// Series returns the values in the window, from oldest to newest.
+func (w *TimeWindow) Series() Series { return w.xs.Copy() }
+
+func (w *TimeWindow) N() int64      { return int64(w.z.n) }
+func (w *TimeWindow) Max() float64  { return Max(w.xs) }
+func (w *TimeWindow) Min() float64  { return Min(w.xs) }
+func (w *TimeWindow) Mean() float64 { return w.z.mean() }
+func (w *TimeWindow) Var() float64  { return w.z.variance(true) }
+func (w *TimeWindow) VarP() float64 { return w.z.variance(false) }
+func (w *TimeWindow) Std() float64  { return math.Sqrt(w.Var()) }
+func (w *TimeWindow) StdP() float64 { return math.Sqrt(w.VarP()) }
+
+// EWMA calculates the exponentially weighted moving mean and variance.
+//
+// The weight of a value halves every half-life, which is measured in
+// number of values if Add is used, and in units of time if AddAt is used.
+// The two methods should not be mixed.
+//
+// The variance is updated incrementally as described by Finch,
+// "Incremental calculation of weighted mean and variance" (2009).
+//
+// An EWMA must be created with NewEWMA, as the zero value has no
+// half-life; Add and AddAt panic if it is used.
+type EWMA struct {
+	h float64
+	n int64
+	t Time
+	m float64
+	v float64
+}
+
+// NewEWMA returns a new EWMA with the given half-life.
+func NewEWMA(halfLife float64) *EWMA {
+	if !(halfLife > 0) {
+		panic("half-life must be positive")
+	}
+	return &EWMA{h: halfLife}
+}
+
+// Reset removes all values, but keeps the half-life.
+func (e *EWMA) Reset() {
+	*e = EWMA{h: e.h}
+}
+
+// HalfLife returns the half-life of the weights.
+func (e *EWMA) HalfLife() float64 { return e.h }
+
+// Add a new value, one unit of time after the previous one.
+func (e *EWMA) Add(x float64) {
+	e.update(1, x)
+}
+
+// AddAt adds a new value at time t. The older values decay according
+// to the time that elapsed since the previous value was added.
+func (e *EWMA) AddAt(t Time, x float64) {
+	if e.h == 0 {
+		panic("EWMA must be created with NewEWMA")
+	} else if e.n > 0 && t < e.t {
+		panic("values must be added in chronological order")
+	}
+	dt := float64(t - e.t)
+	e.t = t
+	e.update(dt, x)
+}
+
+func (e *EWMA) update(dt, x float64) {
+	if e.h == 0 {
+		panic("EWMA must be created with NewEWMA")
+	}
+	e.n++
+	if e.n == 1 {
+		e.m = x
+		e.v = 0
+		return
+	}
+
+	alpha := 1 - math.Exp2(-dt/e.h)
+	d := x - e.m
+	i := alpha * d
+	e.m += i
+	e.v = (1 - alpha) * (e.v + d*i)
+}
+
+// N returns the number of values that have been added.
+func (e *EWMA) N() int64 { return e.n }
+
+// Mean returns the exponentially weighted mean.
+func (e *EWMA) Mean() float64 {
+	if e.n == 0 {
+		return math.NaN()
+	}
+	return e.m
+}
+
+// Var returns the exponentially weighted variance.
+func (e *EWMA) Var() float64 {
+	if e.n == 0 {
+		return math.NaN()
+	}
+	return e.v
+}
+
+// Std returns the exponentially weighted standard deviation.
+func (e *EWMA) Std() float64 {
+	return math.Sqrt(e.Var())
+}
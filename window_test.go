@@ -0,0 +1,97 @@
+// Copyright (c) 2016, Ben Morgan. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMovingWindow(z *testing.T) {
+	assert := assert.New(z)
+
+	r := rand.New(rand.NewSource(1))
+	s := make(Series, 500)
+	w := NewMovingWindow(20)
+	for i := range s {
+		s[i] = r.NormFloat64()*10 + 100
+		w.Add(s[i])
+
+		t := s[:i+1]
+		if len(t) > 20 {
+			t = t[len(t)-20:]
+		}
+		assert.Equal(t, w.Series(), "series at %d", i)
+		assert.Equal(int64(len(t)), w.N(), "n at %d", i)
+		assert.Equal(t.Min(), w.Min(), "min at %d", i)
+		assert.Equal(t.Max(), w.Max(), "max at %d", i)
+		assert.InDelta(t.Mean(), w.Mean(), 1e-9, "mean at %d", i)
+		if i > 0 {
+			assert.InDelta(t.Var(), w.Var(), 1e-9, "var at %d", i)
+			assert.InDelta(t.StdP(), w.StdP(), 1e-9, "stdp at %d", i)
+		}
+	}
+
+	w.Reset()
+	assert.Equal(int64(0), w.N(), "reset")
+	assert.True(math.IsNaN(w.Mean()), "empty window")
+
+	assert.Panics(func() { new(MovingWindow).Add(1) }, "zero value is not usable")
+}
+
+func TestTimeWindow(z *testing.T) {
+	assert := assert.New(z)
+
+	w := NewTimeWindow(10)
+	w.Add(0, 1)
+	w.Add(5, 2)
+	w.Add(9, 3)
+	assert.Equal(Series{1, 2, 3}, w.Series(), "all values within the window")
+	assert.Equal(2.0, w.Mean(), "mean")
+	w.Add(10, 4)
+	assert.Equal(Series{2, 3, 4}, w.Series(), "value at time 0 falls out")
+	assert.Equal([]Time{5, 9, 10}, w.Times(), "times")
+	assert.Equal(3.0, w.Mean(), "mean")
+	assert.Equal(1.0, w.Var(), "var")
+	w.Add(100, 5)
+	assert.Equal(Series{5}, w.Series(), "everything else falls out")
+	assert.Equal(0.0, w.Var(), "var of a single value")
+	assert.Panics(func() { w.Add(99, 0) }, "time may not go backwards")
+	assert.Panics(func() { new(TimeWindow).Add(0, 1) }, "zero value is not usable")
+}
+
+func TestEWMA(z *testing.T) {
+	assert := assert.New(z)
+
+	e := NewEWMA(1)
+	assert.True(math.IsNaN(e.Mean()), "empty")
+	e.Add(0)
+	e.Add(10)
+	assert.Equal(5.0, e.Mean(), "with a half-life of one value, the new value has half the weight")
+	assert.Equal(25.0, e.Var(), "weighted variance of 0 and 10")
+
+	// A constant series converges to the constant, regardless of history.
+	e = NewEWMA(10)
+	e.Add(1000)
+	for i := 0; i < 1000; i++ {
+		e.Add(3)
+	}
+	assert.InDelta(3.0, e.Mean(), 1e-9, "mean converges")
+	assert.InDelta(0.0, e.Std(), 1e-9, "std converges")
+
+	// With time, the decay depends on the elapsed time.
+	e = NewEWMA(2)
+	e.AddAt(0, 0)
+	e.AddAt(2, 10)
+	assert.Equal(5.0, e.Mean(), "one half-life elapsed")
+
+	assert.Panics(func() { new(EWMA).Add(1) }, "zero value is not usable")
+	assert.Panics(func() { new(EWMA).AddAt(0, 1) }, "zero value is not usable")
+	e.AddAt(2, 10)
+	assert.Equal(5.0, e.Mean(), "no time elapsed")
+}